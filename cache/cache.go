@@ -1,5 +1,12 @@
 package cache
 
+import (
+	"io"
+	"time"
+
+	"github.com/AdguardTeam/golibs/timeutil"
+)
+
 // Config - configuration
 type Config struct {
 	// Max. cache size (in bytes) of keys and values.  Default: unlimited
@@ -14,8 +21,25 @@ type Config struct {
 	// When cache is full, the least recently used element is deleted automatically
 	EnableLRU bool
 
-	// User callback function which is called after an element has been deleted automatically
+	// Eviction policy.  If set, EnableLRU is ignored.  Default: [PolicyDefault]
+	Policy Policy
+
+	// User callback function which is called after an element has been deleted automatically,
+	// including when it has expired
 	OnDelete onDeleteType
+
+	// Clock is used to calculate the expiration of elements.  Default: [timeutil.SystemClock]
+	Clock timeutil.Clock
+
+	// Metrics is used for the collection of the cache statistics.  Default: [EmptyMetrics]
+	Metrics Metrics
+
+	// Number of shards.  If greater than 1, the elements are split between the
+	// shards by the hash of the key and each shard has its own lock, which
+	// reduces lock contention.  MaxSize and MaxCount are split evenly between
+	// the shards, so an element may be deleted before the whole cache is full.
+	// Default: 1
+	ShardCount uint
 }
 
 // New - create cache object
@@ -29,6 +53,12 @@ type Cache interface {
 	// Return FALSE if data was added;  TRUE if data was replaced
 	Set(key, val []byte) bool
 
+	// SetWithTTL sets data that expires after ttl.  If ttl is not positive, the
+	// data never expires.  Expired data is deleted lazily on Get or by
+	// DeleteExpired, so it is counted in Stats until then.
+	// Return FALSE if data was added;  TRUE if data was replaced
+	SetWithTTL(key, val []byte, ttl time.Duration) bool
+
 	// Get data
 	// Return nil if item with this key doesn't exist
 	Get(key []byte) []byte
@@ -36,17 +66,52 @@ type Cache interface {
 	// Delete data
 	Del(key []byte)
 
-	// Clear all data and statistics
+	// Peek is like Get but it doesn't make the element recently used for the
+	// eviction policy and doesn't change the statistics.  It also doesn't
+	// delete the element if it has expired.
+	Peek(key []byte) []byte
+
+	// Range calls f for each element that hasn't expired, from the one that
+	// would be deleted first to the one that would be deleted last, until f
+	// returns false.  With several shards, the order is only kept within each
+	// shard.  The elements are collected before calling f, so f may call the
+	// methods of the cache.
+	Range(f func(key, val []byte) (cont bool))
+
+	// DeleteFunc deletes all elements for which del returns true and returns
+	// the number of deleted elements.  OnDelete is not called for them.  del
+	// is called with the cache locked, so it must not call the methods of the
+	// cache.  key must not be modified or retained.
+	DeleteFunc(del func(key, val []byte) (ok bool)) (n int)
+
+	Expirer
+
+	// Clear all data.  The counters in Stats are not reset.
 	Clear()
 
 	// Get statistics data
 	Stats() Stats
+
+	// WriteTo writes the elements that haven't expired to w in a versioned
+	// binary format, from the one that would be deleted first to the one that
+	// would be deleted last.  For LRU, it is from the least recently used to
+	// the most recently used one.
+	io.WriterTo
+
+	// ReadFrom reads the elements written by WriteTo from r and adds them as if
+	// Set or SetWithTTL were called for each of them in the same order.  So,
+	// the limits of the cache and its eviction policy are respected: when the
+	// cache can't hold all elements, with LRU, the least recently used ones are
+	// deleted, and with the default policy and EnableLRU set to false, the
+	// elements that don't fit are not added.  Elements that have expired are
+	// skipped.  If the data is malformed, err is
+	// [ErrBadSnapshot] or an I/O error, and the elements read before the error
+	// remain in the cache.
+	io.ReaderFrom
 }
 
-// Stats - counters
-type Stats struct {
-	Count int
-	Size  int
-	Hit   int
-	Miss  int
+// Expirer is the interface for caches that can delete their expired elements.
+type Expirer interface {
+	// DeleteExpired deletes all expired elements and returns their number.
+	DeleteExpired() (n int)
 }
@@ -0,0 +1,22 @@
+package cache_test
+
+import (
+	"time"
+
+	"github.com/AdguardTeam/golibs/testutil/faketime"
+)
+
+// testTimeout is the common timeout for tests.
+const testTimeout = 1 * time.Second
+
+// testTTL is the common TTL for tests.
+const testTTL = 1 * time.Minute
+
+// newTestClock returns a fake clock that returns the time pointed to by now.
+func newTestClock(now *time.Time) (c *faketime.Clock) {
+	return &faketime.Clock{
+		OnNow: func() (t time.Time) {
+			return *now
+		},
+	}
+}
@@ -1,44 +1,65 @@
 package cache
 
 import (
+	"cmp"
+	"slices"
 	"sync"
-	"sync/atomic"
+	"time"
 	"unsafe"
+
+	"github.com/AdguardTeam/golibs/container"
+	"github.com/AdguardTeam/golibs/timeutil"
 )
 
 type onDeleteType func(key, val []byte)
 
-type cache struct {
-	items map[string]*item
+// typedCache is the generic implementation used by both [Cache] and [Typed].
+type typedCache[K comparable, V any] struct {
+	items map[K]*item[K, V]
 
-	// LRU: for removing the least recently used item on reaching cache size limit
-	// Note: slows down Get() due to an additional work with pointers
-	// Example: [ (sentinel) <-> item1 <-> item2 <-> (sentinel) ]
-	// When the item is accessed, it's moved to the end of the list.
-	usage listItem
+	// policy decides which items are deleted when the cache is full.
+	policy evictionPolicy[K, V]
 
 	lock sync.Mutex
 	size uint // current size in bytes (keys+values)
 
-	conf Config
+	conf TypedConfig[K, V]
+
+	counters counters
+}
+
+type item[K comparable, V any] struct {
+	// expire is the time after which the item is considered expired.  A zero
+	// value means that the item never expires.
+	expire time.Time
+	key    K
+	value  V
+
+	// used links the item into the lists of the list-based policies.
+	used container.ListElement[*item[K, V]]
+
+	// freq, tick, and idx are used by the LFU policy.
+	freq uint64
+	tick uint64
+	idx  int
+
+	// size is the size of the item as reported by the size function.
+	size uint
 
-	// stats:
-	miss int32 // number of misses
-	hit  int32 // number of hits
+	// seg is the segment of the W-TinyLFU policy.
+	seg segment
 }
 
-type item struct {
-	key   []byte
-	value []byte
-	used  listItem
+// isExpired returns true if it has expired by now.
+func (it *item[K, V]) isExpired(now time.Time) (ok bool) {
+	return !it.expire.IsZero() && !now.Before(it.expire)
 }
 
 const maxUint = (1 << (unsafe.Sizeof(uint(0)) * 8)) - 1
 
-func newCache(conf Config) *cache {
-	c := cache{}
-	c.items = make(map[string]*item)
-	listInit(&c.usage)
+func newTypedCache[K comparable, V any](conf TypedConfig[K, V]) *typedCache[K, V] {
+	c := typedCache[K, V]{}
+	c.items = make(map[K]*item[K, V])
 	c.conf = conf
 	if c.conf.MaxSize == 0 {
 		c.conf.MaxSize = maxUint
@@ -52,105 +73,339 @@ func newCache(conf Config) *cache {
 	if c.conf.MaxElementSize > c.conf.MaxSize {
 		c.conf.MaxElementSize = c.conf.MaxSize
 	}
+	if c.conf.Size == nil {
+		c.conf.Size = zeroSize[K, V]
+	}
+	if c.conf.Clock == nil {
+		c.conf.Clock = timeutil.SystemClock{}
+	}
+	if c.conf.Metrics == nil {
+		c.conf.Metrics = EmptyMetrics{}
+	}
+	c.counters.metrics = c.conf.Metrics
+	c.policy = newEvictionPolicy(&c.conf)
 	return &c
 }
 
-func (c *cache) Clear() {
+// zeroSize is the default size function of [TypedConfig].
+func zeroSize[K comparable, V any](_ K, _ V) (n uint) { return 0 }
+
+func (c *typedCache[K, V]) Clear() {
 	c.lock.Lock()
-	c.items = make(map[string]*item)
-	listInit(&c.usage)
+	c.items = make(map[K]*item[K, V])
+	c.policy.reset()
 	c.size = 0
 	c.lock.Unlock()
-	atomic.StoreInt32(&c.hit, 0)
-	atomic.StoreInt32(&c.miss, 0)
 }
 
 // Set value
-func (c *cache) Set(key, val []byte) bool {
-	addSize := uint(len(key) + len(val))
+func (c *typedCache[K, V]) Set(key K, val V) bool {
+	return c.SetWithTTL(key, val, 0)
+}
+
+// SetWithTTL - set value that expires after ttl
+func (c *typedCache[K, V]) SetWithTTL(key K, val V, ttl time.Duration) bool {
+	addSize := c.conf.Size(key, val)
 	if addSize > c.conf.MaxElementSize {
+		c.counters.reject(RejectionReasonTooLarge)
 		return false // too large data
 	}
 
-	it := item{}
+	it := item[K, V]{}
 	it.key = key
 	it.value = val
+	it.size = addSize
+	if ttl > 0 {
+		it.expire = c.conf.Clock.Now().Add(ttl)
+	}
 
 	c.lock.Lock()
 
-	if !c.conf.EnableLRU &&
-		(c.size+addSize > c.conf.MaxSize || uint(len(c.items)) == c.conf.MaxCount) {
+	if !c.makeRoom(addSize) {
 		c.lock.Unlock()
+		c.counters.reject(RejectionReasonFull)
 		return false // cache is full
 	}
 
-	for c.size+addSize > c.conf.MaxSize || uint(len(c.items)) == c.conf.MaxCount {
-		first := listFirst(&c.usage)
-		it := (*item)(structPtr(unsafe.Pointer(first), unsafe.Offsetof(item{}.used)))
-		c.size -= uint(len(it.key) + len(it.value))
-		listUnlink(first)
-		delete(c.items, string(it.key))
-
-		if c.conf.OnDelete != nil {
-			c.lock.Unlock()
-			c.conf.OnDelete(it.key, it.value)
-			c.lock.Lock()
-		}
-	}
-
-	if c.conf.EnableLRU {
-		listAppend(&it.used, listLast(&c.usage))
-	}
-
-	it2, exists := c.items[string(key)]
+	it2, exists := c.items[key]
+	expired := false
 	if exists {
-		listUnlink(&it2.used)
-		c.size -= uint(len(it2.key) + len(it2.value))
+		c.unlink(it2)
+		// An expired value is not considered replaced.
+		expired = !it2.expire.IsZero() && it2.isExpired(c.conf.Clock.Now())
+		exists = !expired
 	}
-	c.items[string(key)] = &it
+	c.items[key] = &it
+	c.policy.add(&it)
 	c.size += addSize
 	c.lock.Unlock()
 
+	if expired {
+		c.onEvicted(it2, EvictionReasonExpired)
+	}
+	c.counters.set(exists)
+
 	return exists
 }
 
+// makeRoom deletes the items chosen by the policy until there is enough room
+// for a new item of size addSize.  ok is false if the policy refuses to delete
+// items.  c.lock is expected to be locked; it is unlocked while the deletions
+// are reported.
+func (c *typedCache[K, V]) makeRoom(addSize uint) (ok bool) {
+	for c.size+addSize > c.conf.MaxSize || uint(len(c.items)) == c.conf.MaxCount {
+		victim := c.policy.victim()
+		if victim == nil {
+			return false
+		}
+
+		reason := EvictionReasonCount
+		if c.size+addSize > c.conf.MaxSize {
+			reason = EvictionReasonSize
+		}
+
+		c.unlink(victim)
+		c.lock.Unlock()
+		c.onEvicted(victim, reason)
+		c.lock.Lock()
+	}
+
+	return true
+}
+
 // Get value
-func (c *cache) Get(key []byte) []byte {
+func (c *typedCache[K, V]) Get(key K) (val V, ok bool) {
 	c.lock.Lock()
-	val, ok := c.items[string(key)]
-	if ok && c.conf.EnableLRU {
-		listUnlink(&val.used)
-		listAppend(&val.used, listLast(&c.usage))
+	it, ok := c.items[key]
+	if ok && !it.expire.IsZero() && it.isExpired(c.conf.Clock.Now()) {
+		// Lazy expiration.
+		c.unlink(it)
+		c.policy.miss(key)
+		c.lock.Unlock()
+		c.onEvicted(it, EvictionReasonExpired)
+		c.counters.lookup(false)
+		return val, false
+	}
+	if ok {
+		c.policy.access(it)
+	} else {
+		c.policy.miss(key)
 	}
 	c.lock.Unlock()
+	c.counters.lookup(ok)
 	if !ok {
-		atomic.AddInt32(&c.miss, 1)
-		return nil
+		return val, false
+	}
+	return it.value, true
+}
+
+// Peek - get value without updating the policy and statistics
+func (c *typedCache[K, V]) Peek(key K) (val V, ok bool) {
+	c.lock.Lock()
+	it, ok := c.items[key]
+	c.lock.Unlock()
+	if !ok || (!it.expire.IsZero() && it.isExpired(c.conf.Clock.Now())) {
+		return val, false
+	}
+	return it.value, true
+}
+
+// Range - call f for each element that hasn't expired
+func (c *typedCache[K, V]) Range(f func(key K, val V) (cont bool)) {
+	for _, it := range c.snapshot() {
+		if !f(it.key, it.value) {
+			return
+		}
+	}
+}
+
+// DeleteFunc - delete all elements for which del returns true
+func (c *typedCache[K, V]) DeleteFunc(del func(key K, val V) (ok bool)) (n int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, it := range c.items {
+		if del(it.key, it.value) {
+			c.unlink(it)
+			n++
+		}
 	}
-	atomic.AddInt32(&c.hit, 1)
-	return val.value
+
+	return n
 }
 
 // Del - delete element
-func (c *cache) Del(key []byte) {
+func (c *typedCache[K, V]) Del(key K) {
 	c.lock.Lock()
-	it, ok := c.items[string(key)]
+	it, ok := c.items[key]
 	if !ok {
 		c.lock.Unlock()
 		return
 	}
-	listUnlink(&it.used)
-	c.size -= uint(len(it.key) + len(it.value))
-	delete(c.items, string(key))
+	c.unlink(it)
 	c.lock.Unlock()
 }
 
+// DeleteExpired - delete all expired elements
+func (c *typedCache[K, V]) DeleteExpired() (n int) {
+	now := c.conf.Clock.Now()
+
+	var expired []*item[K, V]
+	c.lock.Lock()
+	for _, it := range c.items {
+		if it.isExpired(now) {
+			c.unlink(it)
+			expired = append(expired, it)
+		}
+	}
+	c.lock.Unlock()
+
+	for _, it := range expired {
+		c.onEvicted(it, EvictionReasonExpired)
+	}
+
+	return len(expired)
+}
+
+// snapshot - get items that haven't expired in the order of eviction
+func (c *typedCache[K, V]) snapshot() []*item[K, V] {
+	now := c.conf.Clock.Now()
+
+	c.lock.Lock()
+	items := c.policy.appendItems(make([]*item[K, V], 0, len(c.items)))
+	c.lock.Unlock()
+
+	return slices.DeleteFunc(items, func(it *item[K, V]) bool {
+		return it.isExpired(now)
+	})
+}
+
+// unlink removes it from the cache.  c.lock is expected to be locked.
+func (c *typedCache[K, V]) unlink(it *item[K, V]) {
+	c.policy.remove(it)
+	c.size -= it.size
+	delete(c.items, it.key)
+}
+
+// onEvicted records the automatic deletion of it and calls the user callback,
+// if there is one.  c.lock is expected to be unlocked.
+func (c *typedCache[K, V]) onEvicted(it *item[K, V], reason EvictionReason) {
+	c.counters.evict(reason)
+	if c.conf.OnDelete != nil {
+		c.conf.OnDelete(it.key, it.value)
+	}
+}
+
 // GetStats - get counters
-func (c *cache) Stats() Stats {
+func (c *typedCache[K, V]) Stats() Stats {
 	s := Stats{}
+	c.lock.Lock()
 	s.Count = len(c.items)
 	s.Size = int(c.size)
-	s.Hit = int(atomic.LoadInt32(&c.hit))
-	s.Miss = int(atomic.LoadInt32(&c.miss))
+	c.lock.Unlock()
+	c.counters.fill(&s)
 	return s
 }
+
+// bytesCache is the [Cache] implementation that stores keys as strings.
+type bytesCache struct {
+	typed internalTyped[string, []byte]
+	clock timeutil.Clock
+}
+
+func newCache(conf Config) *bytesCache {
+	tc := TypedConfig[string, []byte]{
+		Size:           bytesSize,
+		MaxSize:        conf.MaxSize,
+		MaxElementSize: conf.MaxElementSize,
+		MaxCount:       conf.MaxCount,
+		EnableLRU:      conf.EnableLRU,
+		Policy:         conf.Policy,
+		Clock:          conf.Clock,
+		Metrics:        conf.Metrics,
+		ShardCount:     conf.ShardCount,
+	}
+	if conf.OnDelete != nil {
+		tc.OnDelete = func(key string, val []byte) {
+			conf.OnDelete([]byte(key), val)
+		}
+	}
+	return &bytesCache{
+		typed: newTyped(tc),
+		clock: cmp.Or[timeutil.Clock](conf.Clock, timeutil.SystemClock{}),
+	}
+}
+
+// bytesSize returns the size of the key and the value in bytes.
+func bytesSize(key string, val []byte) (n uint) {
+	return uint(len(key) + len(val))
+}
+
+func (c *bytesCache) Clear() {
+	c.typed.Clear()
+}
+
+// Set value
+func (c *bytesCache) Set(key, val []byte) bool {
+	return c.typed.Set(string(key), val)
+}
+
+// SetWithTTL - set value that expires after ttl
+func (c *bytesCache) SetWithTTL(key, val []byte, ttl time.Duration) bool {
+	return c.typed.SetWithTTL(string(key), val, ttl)
+}
+
+// Get value
+func (c *bytesCache) Get(key []byte) []byte {
+	val, _ := c.typed.Get(tmpString(key))
+	return val
+}
+
+// Del - delete element
+func (c *bytesCache) Del(key []byte) {
+	c.typed.Del(tmpString(key))
+}
+
+// Peek - get value without updating the policy and statistics
+func (c *bytesCache) Peek(key []byte) []byte {
+	val, _ := c.typed.Peek(tmpString(key))
+	return val
+}
+
+// Range - call f for each element that hasn't expired
+func (c *bytesCache) Range(f func(key, val []byte) (cont bool)) {
+	c.typed.Range(func(key string, val []byte) (cont bool) {
+		return f([]byte(key), val)
+	})
+}
+
+// DeleteFunc - delete all elements for which del returns true
+func (c *bytesCache) DeleteFunc(del func(key, val []byte) (ok bool)) (n int) {
+	return c.typed.DeleteFunc(func(key string, val []byte) (ok bool) {
+		return del(tmpBytes(key), val)
+	})
+}
+
+// tmpBytes returns a byte slice that shares memory with s to avoid an
+// allocation.  The result must not be modified or retained after the call that
+// it is passed to.
+func tmpBytes(s string) (b []byte) {
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
+
+// tmpString returns a string that shares memory with b to avoid an allocation
+// on lookups.  The result must not be retained after the call that it is
+// passed to, and b must not be modified during that call.
+func tmpString(b []byte) (s string) {
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// DeleteExpired - delete all expired elements
+func (c *bytesCache) DeleteExpired() (n int) {
+	return c.typed.DeleteExpired()
+}
+
+// GetStats - get counters
+func (c *bytesCache) Stats() Stats {
+	return c.typed.Stats()
+}
@@ -1,2 +1,6 @@
-// Package cache provides a simple LRU cache implementation
+// Package cache provides a simple LRU cache implementation.  [Cache] stores
+// keys and values as byte slices, while [Typed] stores them as values of
+// arbitrary types.  [Loading] loads missing values and deduplicates concurrent
+// loads of the same key.  [NewRedis] and [NewTwoTier] return caches that store
+// their elements in Redis.
 package cache
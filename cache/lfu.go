@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+)
+
+// lfuPolicy is the LFU policy.  The items are kept in a min-heap ordered by
+// the number of uses and then by the time of the last use.
+type lfuPolicy[K comparable, V any] struct {
+	items lfuHeap[K, V]
+
+	// tick is the logical time of the last use.
+	tick uint64
+}
+
+// type check
+var _ evictionPolicy[string, []byte] = (*lfuPolicy[string, []byte])(nil)
+
+// add implements the [evictionPolicy] interface for *lfuPolicy.
+func (p *lfuPolicy[K, V]) add(it *item[K, V]) {
+	p.tick++
+	it.freq = 1
+	it.tick = p.tick
+	heap.Push(&p.items, it)
+}
+
+// access implements the [evictionPolicy] interface for *lfuPolicy.
+func (p *lfuPolicy[K, V]) access(it *item[K, V]) {
+	p.tick++
+	it.freq++
+	it.tick = p.tick
+	heap.Fix(&p.items, it.idx)
+}
+
+// miss implements the [evictionPolicy] interface for *lfuPolicy.
+func (p *lfuPolicy[K, V]) miss(_ K) {}
+
+// remove implements the [evictionPolicy] interface for *lfuPolicy.
+func (p *lfuPolicy[K, V]) remove(it *item[K, V]) {
+	heap.Remove(&p.items, it.idx)
+}
+
+// victim implements the [evictionPolicy] interface for *lfuPolicy.
+func (p *lfuPolicy[K, V]) victim() (it *item[K, V]) {
+	if len(p.items) == 0 {
+		return nil
+	}
+
+	return p.items[0]
+}
+
+// reset implements the [evictionPolicy] interface for *lfuPolicy.
+func (p *lfuPolicy[K, V]) reset() {
+	clear(p.items)
+	p.items = p.items[:0]
+	p.tick = 0
+}
+
+// appendItems implements the [evictionPolicy] interface for *lfuPolicy.  The
+// items are appended from the least frequently used one.
+func (p *lfuPolicy[K, V]) appendItems(items []*item[K, V]) (res []*item[K, V]) {
+	l := len(items)
+	res = append(items, p.items...)
+	slices.SortFunc(res[l:], compareLFU)
+
+	return res
+}
+
+// compareLFU compares the items by their frequency and then by their last use.
+func compareLFU[K comparable, V any](a, b *item[K, V]) (res int) {
+	if a.freq != b.freq {
+		return cmp.Compare(a.freq, b.freq)
+	}
+
+	return cmp.Compare(a.tick, b.tick)
+}
+
+// lfuHeap is a min-heap of items ordered by their frequency and then by their
+// last use.
+type lfuHeap[K comparable, V any] []*item[K, V]
+
+// type check
+var _ heap.Interface = (*lfuHeap[string, []byte])(nil)
+
+// Len implements the [heap.Interface] interface for *lfuHeap.
+func (h *lfuHeap[K, V]) Len() (n int) { return len(*h) }
+
+// Less implements the [heap.Interface] interface for *lfuHeap.
+func (h *lfuHeap[K, V]) Less(i, j int) (less bool) {
+	return compareLFU((*h)[i], (*h)[j]) < 0
+}
+
+// Swap implements the [heap.Interface] interface for *lfuHeap.
+func (h *lfuHeap[K, V]) Swap(i, j int) {
+	s := *h
+	s[i], s[j] = s[j], s[i]
+	s[i].idx = i
+	s[j].idx = j
+}
+
+// Push implements the [heap.Interface] interface for *lfuHeap.  x must be an
+// *item.
+func (h *lfuHeap[K, V]) Push(x any) {
+	it := x.(*item[K, V])
+	it.idx = len(*h)
+	*h = append(*h, it)
+}
+
+// Pop implements the [heap.Interface] interface for *lfuHeap.
+func (h *lfuHeap[K, V]) Pop() (x any) {
+	s := *h
+	n := len(s) - 1
+	it := s[n]
+	s[n] = nil
+	*h = s[:n]
+	it.idx = -1
+
+	return it
+}
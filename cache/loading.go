@@ -0,0 +1,293 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AdguardTeam/golibs/contextutil"
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/AdguardTeam/golibs/logutil/slogutil"
+	"github.com/AdguardTeam/golibs/service"
+	"github.com/AdguardTeam/golibs/timeutil"
+)
+
+// ErrLoadPanicked is returned by [Loading.GetOrLoad] to the callers waiting for
+// a loader that has panicked.  The panic itself is propagated to the caller
+// that has called the loader.
+const ErrLoadPanicked errors.Error = "loader panicked"
+
+// LoadFunc loads the value for a key that is missing from a [Loading] cache.
+// ttl is the time during which val is considered fresh.  If ttl is not
+// positive, val never expires.  If err is not nil, val is not cached.
+type LoadFunc[K comparable, V any] func(
+	ctx context.Context,
+	key K,
+) (val V, ttl time.Duration, err error)
+
+// LoadingConfig is the configuration for a [Loading] cache.
+type LoadingConfig[K comparable, V any] struct {
+	// ContextConstructor is used to create contexts for the background reloads
+	// of stale elements.  The parent context is the context passed to
+	// [Loading.GetOrLoad] without its cancellation.  If it is nil,
+	// [contextutil.EmptyConstructor] is used.
+	ContextConstructor contextutil.Constructor
+
+	// ErrorHandler handles the errors of the background reloads of stale
+	// elements.  If it is nil, [service.IgnoreErrorHandler] is used.
+	ErrorHandler service.ErrorHandler
+
+	// Cache is the configuration of the underlying cache.  Its Size and
+	// OnDelete functions receive the loaded values.
+	Cache TypedConfig[K, V]
+
+	// StaleTTL is the time during which an element is still returned after
+	// its TTL has passed, while the element is reloaded in the background.  If
+	// it is zero, elements are deleted once their TTL has passed, and the next
+	// call to [Loading.GetOrLoad] waits for the loader.
+	StaleTTL time.Duration
+}
+
+// Loading is a cache that loads missing values with a [LoadFunc].  Concurrent
+// calls to [Loading.GetOrLoad] for the same missing key share a single call to
+// the loader.
+type Loading[K comparable, V any] struct {
+	cache       Typed[K, loadingEntry[V]]
+	clock       timeutil.Clock
+	contextCons contextutil.Constructor
+	errHdlr     service.ErrorHandler
+
+	// callsMu protects calls.
+	callsMu *sync.Mutex
+
+	// calls contains the loader calls that are currently in progress.
+	calls map[K]*loadCall[V]
+
+	staleTTL time.Duration
+}
+
+// type check
+var _ Expirer = (*Loading[string, []byte])(nil)
+
+// loadingEntry is an element of a [Loading] cache.
+type loadingEntry[V any] struct {
+	// fresh is the time after which the value is stale.  If it is zero, the
+	// value is never stale.
+	fresh time.Time
+
+	val V
+}
+
+// loadCall is a loader call in progress.
+type loadCall[V any] struct {
+	// done is closed once val and err are set.
+	done chan struct{}
+
+	err error
+	val V
+}
+
+// NewLoading returns a new properly initialized *Loading.  c must not be nil.
+func NewLoading[K comparable, V any](c *LoadingConfig[K, V]) (l *Loading[K, V]) {
+	conf := TypedConfig[K, loadingEntry[V]]{
+		Clock:          c.Cache.Clock,
+		Metrics:        c.Cache.Metrics,
+		MaxSize:        c.Cache.MaxSize,
+		MaxElementSize: c.Cache.MaxElementSize,
+		MaxCount:       c.Cache.MaxCount,
+		ShardCount:     c.Cache.ShardCount,
+		EnableLRU:      c.Cache.EnableLRU,
+		Policy:         c.Cache.Policy,
+	}
+
+	if size := c.Cache.Size; size != nil {
+		conf.Size = func(key K, e loadingEntry[V]) (n uint) {
+			return size(key, e.val)
+		}
+	}
+
+	if onDelete := c.Cache.OnDelete; onDelete != nil {
+		conf.OnDelete = func(key K, e loadingEntry[V]) {
+			onDelete(key, e.val)
+		}
+	}
+
+	if conf.Clock == nil {
+		conf.Clock = timeutil.SystemClock{}
+	}
+
+	l = &Loading[K, V]{
+		cache:       NewTyped(conf),
+		clock:       conf.Clock,
+		contextCons: c.ContextConstructor,
+		errHdlr:     c.ErrorHandler,
+		callsMu:     &sync.Mutex{},
+		calls:       map[K]*loadCall[V]{},
+		staleTTL:    c.StaleTTL,
+	}
+
+	if l.contextCons == nil {
+		l.contextCons = contextutil.EmptyConstructor{}
+	}
+
+	if l.errHdlr == nil {
+		l.errHdlr = service.IgnoreErrorHandler{}
+	}
+
+	return l
+}
+
+// GetOrLoad returns the value for key.  If the value is missing, GetOrLoad
+// calls load and caches its result, unless load returns an error.  Concurrent
+// calls for the same key share a single call to a loader, which uses the
+// context of the first caller; the other callers stop waiting once their
+// contexts are canceled.
+//
+// If the value is stale, GetOrLoad returns it and reloads it in the
+// background, unless a call to a loader for key is already in progress.
+func (l *Loading[K, V]) GetOrLoad(
+	ctx context.Context,
+	key K,
+	load LoadFunc[K, V],
+) (val V, err error) {
+	e, ok := l.cache.Get(key)
+	if !ok {
+		return l.load(ctx, key, load)
+	}
+
+	if !e.fresh.IsZero() && !l.clock.Now().Before(e.fresh) {
+		l.reload(ctx, key, load)
+	}
+
+	return e.val, nil
+}
+
+// load calls load or waits for the call in progress.
+func (l *Loading[K, V]) load(
+	ctx context.Context,
+	key K,
+	load LoadFunc[K, V],
+) (val V, err error) {
+	call, isNew := l.startCall(key)
+	if isNew {
+		l.do(ctx, key, load, call)
+
+		return call.val, call.err
+	}
+
+	select {
+	case <-call.done:
+		return call.val, call.err
+	case <-ctx.Done():
+		return val, ctx.Err()
+	}
+}
+
+// reload reloads the stale value for key in the background, unless a call to
+// a loader for key is already in progress.
+func (l *Loading[K, V]) reload(ctx context.Context, key K, load LoadFunc[K, V]) {
+	call, isNew := l.startCall(key)
+	if !isNew {
+		return
+	}
+
+	go func() {
+		reloadCtx, cancel := l.contextCons.New(context.WithoutCancel(ctx))
+		defer cancel()
+
+		defer slogutil.RecoverAndLogDefault(reloadCtx)
+
+		l.do(reloadCtx, key, load, call)
+		if call.err != nil {
+			l.errHdlr.Handle(reloadCtx, fmt.Errorf("cache: reloading: %w", call.err))
+		}
+	}()
+}
+
+// startCall returns the call in progress for key or a new one, in which case
+// isNew is true and the caller must call [Loading.do].
+func (l *Loading[K, V]) startCall(key K) (call *loadCall[V], isNew bool) {
+	l.callsMu.Lock()
+	defer l.callsMu.Unlock()
+
+	call, ok := l.calls[key]
+	if ok {
+		return call, false
+	}
+
+	call = &loadCall[V]{
+		done: make(chan struct{}),
+	}
+	l.calls[key] = call
+
+	return call, true
+}
+
+// do calls load, caches its result, and finishes call.
+func (l *Loading[K, V]) do(
+	ctx context.Context,
+	key K,
+	load LoadFunc[K, V],
+	call *loadCall[V],
+) {
+	// Make sure that the waiters are released even if load panics.
+	call.err = ErrLoadPanicked
+	defer l.finishCall(key, call)
+
+	val, ttl, err := load(ctx, key)
+	if err != nil {
+		call.err = err
+
+		return
+	}
+
+	call.val, call.err = val, nil
+	l.set(key, val, ttl)
+}
+
+// finishCall removes call from the calls in progress and releases its waiters.
+func (l *Loading[K, V]) finishCall(key K, call *loadCall[V]) {
+	l.callsMu.Lock()
+	defer l.callsMu.Unlock()
+
+	delete(l.calls, key)
+	close(call.done)
+}
+
+// set caches val, which is fresh for ttl, and keeps it for an additional
+// stale TTL.
+func (l *Loading[K, V]) set(key K, val V, ttl time.Duration) {
+	e := loadingEntry[V]{
+		val: val,
+	}
+
+	if ttl <= 0 {
+		_ = l.cache.Set(key, e)
+
+		return
+	}
+
+	e.fresh = l.clock.Now().Add(ttl)
+	_ = l.cache.SetWithTTL(key, e, ttl+l.staleTTL)
+}
+
+// Del deletes the value for key.  It doesn't affect a loader call in progress.
+func (l *Loading[K, V]) Del(key K) {
+	l.cache.Del(key)
+}
+
+// Clear deletes all values.  It doesn't affect the loader calls in progress.
+func (l *Loading[K, V]) Clear() {
+	l.cache.Clear()
+}
+
+// DeleteExpired implements the [Expirer] interface for *Loading.
+func (l *Loading[K, V]) DeleteExpired() (n int) {
+	return l.cache.DeleteExpired()
+}
+
+// Stats returns the statistics of the underlying cache.
+func (l *Loading[K, V]) Stats() (s Stats) {
+	return l.cache.Stats()
+}
@@ -0,0 +1,216 @@
+package cache_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AdguardTeam/golibs/cache"
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/AdguardTeam/golibs/service"
+	"github.com/AdguardTeam/golibs/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newConstLoader returns a loader that returns val with ttl and increments
+// calls.
+func newConstLoader(
+	calls *atomic.Int64,
+	val int,
+	ttl time.Duration,
+) (load cache.LoadFunc[string, int]) {
+	return func(_ context.Context, _ string) (v int, t time.Duration, err error) {
+		calls.Add(1)
+
+		return val, ttl, nil
+	}
+}
+
+func TestLoading_GetOrLoad(t *testing.T) {
+	t.Parallel()
+
+	c := cache.NewLoading(&cache.LoadingConfig[string, int]{
+		Cache: cache.TypedConfig[string, int]{
+			MaxCount: 10,
+		},
+	})
+
+	const (
+		goroutinesNum = 10
+		key           = "key"
+		val           = 42
+	)
+
+	var calls atomic.Int64
+	release := make(chan struct{})
+	load := func(_ context.Context, _ string) (v int, ttl time.Duration, err error) {
+		calls.Add(1)
+		<-release
+
+		return val, 0, nil
+	}
+
+	ctx := testutil.ContextWithTimeout(t, testTimeout)
+
+	wg := &sync.WaitGroup{}
+	results := make([]int, goroutinesNum)
+	for i := range goroutinesNum {
+		wg.Go(func() {
+			var err error
+			results[i], err = c.GetOrLoad(ctx, key, load)
+			assert.NoError(t, err)
+		})
+	}
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int64(1), calls.Load())
+	for _, r := range results {
+		assert.Equal(t, val, r)
+	}
+
+	got, err := c.GetOrLoad(ctx, key, load)
+	require.NoError(t, err)
+
+	assert.Equal(t, val, got)
+	assert.Equal(t, int64(1), calls.Load())
+}
+
+func TestLoading_GetOrLoad_error(t *testing.T) {
+	t.Parallel()
+
+	c := cache.NewLoading(&cache.LoadingConfig[string, int]{
+		Cache: cache.TypedConfig[string, int]{
+			MaxCount: 10,
+		},
+	})
+
+	const testError errors.Error = "test error"
+
+	ctx := testutil.ContextWithTimeout(t, testTimeout)
+	_, err := c.GetOrLoad(ctx, "key", func(_ context.Context, _ string) (int, time.Duration, error) {
+		return 0, 0, testError
+	})
+	require.ErrorIs(t, err, testError)
+
+	assert.Zero(t, c.Stats().Count)
+
+	assert.Panics(t, func() {
+		_, _ = c.GetOrLoad(ctx, "key", func(_ context.Context, _ string) (int, time.Duration, error) {
+			panic(testError)
+		})
+	})
+
+	var calls atomic.Int64
+	got, err := c.GetOrLoad(ctx, "key", newConstLoader(&calls, 1, 0))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, got)
+	assert.Equal(t, int64(1), calls.Load())
+}
+
+func TestLoading_GetOrLoad_canceled(t *testing.T) {
+	t.Parallel()
+
+	c := cache.NewLoading(&cache.LoadingConfig[string, int]{
+		Cache: cache.TypedConfig[string, int]{
+			MaxCount: 10,
+		},
+	})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	load := func(_ context.Context, _ string) (v int, ttl time.Duration, err error) {
+		close(started)
+		<-release
+
+		return 1, 0, nil
+	}
+
+	ctx := testutil.ContextWithTimeout(t, testTimeout)
+
+	wg := &sync.WaitGroup{}
+	wg.Go(func() {
+		got, err := c.GetOrLoad(ctx, "key", load)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got)
+	})
+
+	testutil.RequireReceive(t, started, testTimeout)
+
+	waiterCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err := c.GetOrLoad(waiterCtx, "key", load)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	close(release)
+	wg.Wait()
+}
+
+func TestLoading_GetOrLoad_stale(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	reloadErrs := make(chan error, 1)
+	c := cache.NewLoading(&cache.LoadingConfig[string, int]{
+		ErrorHandler: service.ErrorHandlerFunc(func(_ context.Context, err error) {
+			reloadErrs <- err
+		}),
+		Cache: cache.TypedConfig[string, int]{
+			Clock:    newTestClock(&now),
+			MaxCount: 10,
+		},
+		StaleTTL: testTTL,
+	})
+
+	const key = "key"
+
+	ctx := testutil.ContextWithTimeout(t, testTimeout)
+
+	var calls atomic.Int64
+	got, err := c.GetOrLoad(ctx, key, newConstLoader(&calls, 1, testTTL))
+	require.NoError(t, err)
+	require.Equal(t, 1, got)
+
+	now = now.Add(testTTL)
+
+	// The stale value is returned, and the new one is loaded in the
+	// background.
+	got, err = c.GetOrLoad(ctx, key, newConstLoader(&calls, 2, testTTL))
+	require.NoError(t, err)
+	require.Equal(t, 1, got)
+
+	require.Eventually(t, func() (ok bool) {
+		got, err = c.GetOrLoad(ctx, key, newConstLoader(&calls, 2, testTTL))
+
+		return err == nil && got == 2
+	}, testTimeout, testTimeout/100)
+
+	// Errors of background reloads are handled by the error handler, and the
+	// stale value is kept.
+	now = now.Add(testTTL)
+
+	const testError errors.Error = "test error"
+
+	got, err = c.GetOrLoad(ctx, key, func(_ context.Context, _ string) (int, time.Duration, error) {
+		return 0, 0, testError
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, got)
+
+	reloadErr, _ := testutil.RequireReceive(t, reloadErrs, testTimeout)
+	assert.ErrorIs(t, reloadErr, testError)
+
+	// Once the stale TTL has passed, the value is loaded synchronously.
+	now = now.Add(testTTL)
+
+	got, err = c.GetOrLoad(ctx, key, newConstLoader(&calls, 3, testTTL))
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, got)
+}
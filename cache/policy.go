@@ -0,0 +1,158 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/AdguardTeam/golibs/container"
+	"github.com/AdguardTeam/golibs/errors"
+)
+
+// Policy is the eviction policy of a cache, which decides which elements are
+// deleted when the cache is full.
+type Policy string
+
+// Valid policies.
+const (
+	// PolicyDefault is the LRU policy if EnableLRU is true.  Otherwise, Set
+	// refuses to add new elements to a full cache.
+	PolicyDefault Policy = ""
+
+	// PolicyLRU deletes the least recently used element.  It is the same as
+	// setting EnableLRU to true.
+	PolicyLRU Policy = "lru"
+
+	// PolicyLFU deletes the least frequently used element.  Among the elements
+	// with the same number of uses, the least recently used one is deleted.
+	PolicyLFU Policy = "lfu"
+
+	// PolicyWTinyLFU is the W-TinyLFU policy.  New elements are added into a
+	// small LRU window, and an element leaving the window is only admitted into
+	// the main segmented LRU part of the cache if it has been used more
+	// frequently than the element that it would displace.  The frequencies are
+	// estimated with a periodically aged count-min sketch, so elements that
+	// have been deleted are still remembered for a while.  This policy is
+	// resistant to scans, such as random-subdomain floods.
+	PolicyWTinyLFU Policy = "w-tinylfu"
+)
+
+// NewPolicy returns a new valid policy.
+func NewPolicy(s string) (p Policy, err error) {
+	switch p = Policy(s); p {
+	case
+		PolicyDefault,
+		PolicyLRU,
+		PolicyLFU,
+		PolicyWTinyLFU:
+		return p, nil
+	default:
+		return "", fmt.Errorf("policy: %w: %q", errors.ErrBadEnumValue, s)
+	}
+}
+
+// evictionPolicy is the internal interface for eviction policies.  All methods
+// are called with the cache locked.
+type evictionPolicy[K comparable, V any] interface {
+	// add adds a new item to the policy.
+	add(it *item[K, V])
+
+	// access records a use of an item that has been added.
+	access(it *item[K, V])
+
+	// miss records a lookup of a key that is not in the cache.
+	miss(key K)
+
+	// remove removes an item that has been added.
+	remove(it *item[K, V])
+
+	// victim returns the item that should be deleted to free space for a new
+	// one.  If victim returns nil, no items should be deleted and the new item
+	// should not be added.
+	victim() (it *item[K, V])
+
+	// reset removes all items from the policy.
+	reset()
+
+	// appendItems appends all items to items in the order in which they would
+	// be deleted, as far as the policy can tell, and returns the result.
+	appendItems(items []*item[K, V]) (res []*item[K, V])
+}
+
+// newEvictionPolicy returns a new policy for the configuration.  conf must not
+// be nil and must have the defaults set.
+func newEvictionPolicy[K comparable, V any](conf *TypedConfig[K, V]) (p evictionPolicy[K, V]) {
+	switch conf.Policy {
+	case PolicyDefault:
+		return newLRUPolicy[K, V](!conf.EnableLRU)
+	case PolicyLRU:
+		return newLRUPolicy[K, V](false)
+	case PolicyLFU:
+		return &lfuPolicy[K, V]{}
+	case PolicyWTinyLFU:
+		return newWTinyLFUPolicy[K, V](conf.MaxSize, conf.MaxCount)
+	default:
+		panic(fmt.Errorf("cache: policy: %w: %q", errors.ErrBadEnumValue, conf.Policy))
+	}
+}
+
+// lruPolicy is the LRU policy.  It is also used when the cache should refuse
+// new items when it is full, in which case the items are kept in the insertion
+// order.
+type lruPolicy[K comparable, V any] struct {
+	// usage is the list of items.  When the item is accessed, it's moved to
+	// the end of the list.
+	usage container.LinkedList[*item[K, V]]
+
+	// refuse, if true, means that the items are never moved and deleted.
+	refuse bool
+}
+
+// newLRUPolicy returns a new properly initialized *lruPolicy.
+func newLRUPolicy[K comparable, V any](refuse bool) (p *lruPolicy[K, V]) {
+	return &lruPolicy[K, V]{
+		refuse: refuse,
+	}
+}
+
+// type check
+var _ evictionPolicy[string, []byte] = (*lruPolicy[string, []byte])(nil)
+
+// add implements the [evictionPolicy] interface for *lruPolicy.
+func (p *lruPolicy[K, V]) add(it *item[K, V]) {
+	it.used.Value = it
+	p.usage.PushBackElement(&it.used)
+}
+
+// access implements the [evictionPolicy] interface for *lruPolicy.
+func (p *lruPolicy[K, V]) access(it *item[K, V]) {
+	if !p.refuse {
+		p.usage.MoveToBack(&it.used)
+	}
+}
+
+// miss implements the [evictionPolicy] interface for *lruPolicy.
+func (p *lruPolicy[K, V]) miss(_ K) {}
+
+// remove implements the [evictionPolicy] interface for *lruPolicy.
+func (p *lruPolicy[K, V]) remove(it *item[K, V]) {
+	p.usage.Remove(&it.used)
+}
+
+// victim implements the [evictionPolicy] interface for *lruPolicy.
+func (p *lruPolicy[K, V]) victim() (it *item[K, V]) {
+	if p.refuse || p.usage.Len() == 0 {
+		return nil
+	}
+
+	return p.usage.Front().Value
+}
+
+// reset implements the [evictionPolicy] interface for *lruPolicy.
+func (p *lruPolicy[K, V]) reset() {
+	p.usage.Clear()
+}
+
+// appendItems implements the [evictionPolicy] interface for *lruPolicy.  The
+// items are appended from the least recently used or the earliest added one.
+func (p *lruPolicy[K, V]) appendItems(items []*item[K, V]) (res []*item[K, V]) {
+	return append(items, p.usage.Values()...)
+}
@@ -0,0 +1,211 @@
+package cache_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/cache"
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/AdguardTeam/golibs/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPolicy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		in         string
+		wantErrMsg string
+		want       cache.Policy
+	}{{
+		name:       "default",
+		in:         "",
+		wantErrMsg: "",
+		want:       cache.PolicyDefault,
+	}, {
+		name:       "lfu",
+		in:         "lfu",
+		wantErrMsg: "",
+		want:       cache.PolicyLFU,
+	}, {
+		name:       "w-tinylfu",
+		in:         "w-tinylfu",
+		wantErrMsg: "",
+		want:       cache.PolicyWTinyLFU,
+	}, {
+		name:       "bad",
+		in:         "mru",
+		wantErrMsg: `policy: bad enum value: "mru"`,
+		want:       "",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := cache.NewPolicy(tc.in)
+			testutil.AssertErrorMsg(t, tc.wantErrMsg, err)
+			assert.Equal(t, tc.want, p)
+		})
+	}
+}
+
+func TestNew_policyBad(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithError(t, `cache: policy: bad enum value: "mru"`, func() {
+		_ = cache.New(cache.Config{
+			Policy: "mru",
+		})
+	})
+
+	_, err := cache.NewPolicy("mru")
+	assert.ErrorIs(t, err, errors.ErrBadEnumValue)
+}
+
+func TestNew_policyLRU(t *testing.T) {
+	t.Parallel()
+
+	c := cache.New(cache.Config{
+		MaxCount: 2,
+		Policy:   cache.PolicyLRU,
+	})
+
+	require.False(t, c.Set([]byte("k1"), []byte("v1")))
+	require.False(t, c.Set([]byte("k2"), []byte("v2")))
+	require.NotNil(t, c.Get([]byte("k1")))
+	require.False(t, c.Set([]byte("k3"), []byte("v3")))
+
+	assert.NotNil(t, c.Get([]byte("k1")))
+	assert.Nil(t, c.Get([]byte("k2")))
+	assert.NotNil(t, c.Get([]byte("k3")))
+}
+
+func TestNew_policyLFU(t *testing.T) {
+	t.Parallel()
+
+	var deleted []string
+	c := cache.New(cache.Config{
+		OnDelete: func(key, _ []byte) {
+			deleted = append(deleted, string(key))
+		},
+		MaxCount: 3,
+		Policy:   cache.PolicyLFU,
+	})
+
+	require.False(t, c.Set([]byte("k1"), []byte("v1")))
+	require.False(t, c.Set([]byte("k2"), []byte("v2")))
+	require.False(t, c.Set([]byte("k3"), []byte("v3")))
+
+	require.NotNil(t, c.Get([]byte("k1")))
+	require.NotNil(t, c.Get([]byte("k1")))
+	require.NotNil(t, c.Get([]byte("k3")))
+
+	require.False(t, c.Set([]byte("k4"), []byte("v4")))
+	require.False(t, c.Set([]byte("k5"), []byte("v5")))
+
+	c.Del([]byte("k1"))
+	require.False(t, c.Set([]byte("k6"), []byte("v6")))
+
+	assert.Equal(t, []string{"k2", "k4"}, deleted)
+	assert.Equal(t, 3, c.Stats().Count)
+
+	c.Clear()
+	require.False(t, c.Set([]byte("k1"), []byte("v1")))
+	assert.Equal(t, []byte("v1"), c.Get([]byte("k1")))
+}
+
+// getOrSet gets key from c and sets it if it's not there.
+func getOrSet(c cache.Cache, key []byte) {
+	if c.Get(key) == nil {
+		_ = c.Set(key, key)
+	}
+}
+
+// scan simulates a set of frequently used keys followed by a scan of unique
+// keys, such as a random-subdomain flood, and returns the number of frequently
+// used keys that remain in c.
+func scan(tb testing.TB, c cache.Cache, hotNum, scanNum int) (remaining int) {
+	tb.Helper()
+
+	for i := range 10 * hotNum {
+		getOrSet(c, []byte(strconv.Itoa(i%hotNum)))
+	}
+
+	for i := range scanNum {
+		getOrSet(c, fmt.Appendf(nil, "%d.random.example", i))
+
+		// Keep using the frequently used keys, but not often enough for LRU.
+		if i%2 == 0 {
+			getOrSet(c, []byte(strconv.Itoa(i/2%hotNum)))
+		}
+	}
+
+	for i := range hotNum {
+		if c.Get([]byte(strconv.Itoa(i))) != nil {
+			remaining++
+		}
+	}
+
+	return remaining
+}
+
+func TestNew_policyWTinyLFU(t *testing.T) {
+	t.Parallel()
+
+	const (
+		maxCount = 100
+		hotNum   = maxCount / 2
+		scanNum  = maxCount * 100
+	)
+
+	lru := cache.New(cache.Config{
+		MaxCount: maxCount,
+		Policy:   cache.PolicyLRU,
+	})
+	// LRU only keeps the frequently used keys that have been used recently.
+	assert.Less(t, scan(t, lru, hotNum, scanNum), hotNum*3/4)
+
+	tinyLFU := cache.New(cache.Config{
+		MaxCount: maxCount,
+		Policy:   cache.PolicyWTinyLFU,
+	})
+	assert.GreaterOrEqual(t, scan(t, tinyLFU, hotNum, scanNum), hotNum*9/10)
+
+	s := tinyLFU.Stats()
+	assert.LessOrEqual(t, s.Count, maxCount)
+
+	assert.Positive(t, s.EvictCount)
+
+	tinyLFU.Clear()
+	assert.Zero(t, tinyLFU.Stats().Count)
+}
+
+func TestNew_policyWTinyLFUSize(t *testing.T) {
+	t.Parallel()
+
+	const maxSize = 1000
+
+	c := cache.New(cache.Config{
+		MaxSize: maxSize,
+		Policy:  cache.PolicyWTinyLFU,
+	})
+
+	for i := range maxSize {
+		key := []byte(strconv.Itoa(i))
+		_ = c.Set(key, key)
+		_ = c.Get(key)
+
+		assert.LessOrEqual(t, c.Stats().Size, maxSize)
+	}
+
+	for i := range maxSize {
+		c.Del([]byte(strconv.Itoa(i)))
+	}
+
+	assert.Equal(t, 0, c.Stats().Count)
+	assert.Equal(t, 0, c.Stats().Size)
+}
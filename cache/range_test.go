@@ -0,0 +1,145 @@
+package cache_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/AdguardTeam/golibs/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_Peek(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	c := cache.New(cache.Config{
+		Clock:     newTestClock(&now),
+		MaxCount:  2,
+		EnableLRU: true,
+	})
+
+	require.False(t, c.Set([]byte("k1"), []byte("v1")))
+	require.False(t, c.SetWithTTL([]byte("k2"), []byte("v2"), testTTL))
+
+	assert.Equal(t, []byte("v1"), c.Peek([]byte("k1")))
+	assert.Nil(t, c.Peek([]byte("k3")))
+
+	// Peek doesn't make k1 recently used, so it is deleted.
+	require.False(t, c.Set([]byte("k3"), []byte("v3")))
+	assert.Nil(t, c.Peek([]byte("k1")))
+
+	now = now.Add(testTTL)
+	assert.Nil(t, c.Peek([]byte("k2")))
+
+	s := c.Stats()
+	assert.Equal(t, 2, s.Count)
+	assert.Zero(t, s.Hit)
+	assert.Zero(t, s.Miss)
+}
+
+func TestCache_Range(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	c := cache.New(cache.Config{
+		Clock:     newTestClock(&now),
+		EnableLRU: true,
+	})
+
+	require.False(t, c.Set([]byte("k1"), []byte("v1")))
+	require.False(t, c.Set([]byte("k2"), []byte("v2")))
+	require.False(t, c.SetWithTTL([]byte("k3"), []byte("v3"), testTTL))
+	require.False(t, c.Set([]byte("k4"), []byte("v4")))
+	require.NotNil(t, c.Get([]byte("k1")))
+
+	now = now.Add(testTTL)
+
+	var keys []string
+	c.Range(func(key, val []byte) (cont bool) {
+		keys = append(keys, string(key))
+
+		// Make sure that the cache can be used inside f.
+		c.Del(key)
+
+		return len(keys) < 2
+	})
+
+	assert.Equal(t, []string{"k2", "k4"}, keys)
+	assert.Equal(t, 2, c.Stats().Count)
+}
+
+func TestCache_DeleteFunc(t *testing.T) {
+	t.Parallel()
+
+	var deleted [][]byte
+	c := cache.New(cache.Config{
+		OnDelete: func(key, _ []byte) {
+			deleted = append(deleted, key)
+		},
+		ShardCount: 4,
+	})
+
+	domains := []string{
+		"example.com",
+		"www.example.com",
+		"example.org",
+		"mail.example.org",
+		"www.example.net",
+	}
+
+	for _, d := range domains {
+		require.False(t, c.Set([]byte(d), []byte(d)))
+	}
+
+	n := c.DeleteFunc(func(key, _ []byte) (ok bool) {
+		return bytes.HasSuffix(key, []byte("example.org"))
+	})
+	assert.Equal(t, 2, n)
+	assert.Empty(t, deleted)
+
+	assert.Nil(t, c.Get([]byte("example.org")))
+	assert.Nil(t, c.Get([]byte("mail.example.org")))
+	assert.NotNil(t, c.Get([]byte("example.com")))
+
+	s := c.Stats()
+	assert.Equal(t, 3, s.Count)
+	assert.Equal(t, 2*len("example.com")+2*len("www.example.com")+2*len("www.example.net"), s.Size)
+}
+
+func TestTyped_Range(t *testing.T) {
+	t.Parallel()
+
+	c := cache.NewTyped(cache.TypedConfig[int, testValue]{
+		Policy: cache.PolicyLFU,
+	})
+
+	require.False(t, c.Set(1, testValue{num: 1}))
+	require.False(t, c.Set(2, testValue{num: 2}))
+	require.False(t, c.Set(3, testValue{num: 3}))
+
+	_, ok := c.Get(1)
+	require.True(t, ok)
+
+	v, ok := c.Peek(2)
+	require.True(t, ok)
+	assert.Equal(t, 2, v.num)
+
+	var keys []int
+	c.Range(func(key int, _ testValue) (cont bool) {
+		keys = append(keys, key)
+
+		return true
+	})
+
+	assert.Equal(t, []int{2, 3, 1}, keys)
+
+	n := c.DeleteFunc(func(_ int, val testValue) (ok bool) {
+		return val.num%2 == 1
+	})
+	assert.Equal(t, 2, n)
+
+	_, ok = c.Peek(2)
+	assert.True(t, ok)
+}
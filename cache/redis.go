@@ -0,0 +1,585 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/AdguardTeam/golibs/contextutil"
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/AdguardTeam/golibs/redisutil"
+	"github.com/AdguardTeam/golibs/service"
+	"github.com/AdguardTeam/golibs/timeutil"
+	"github.com/AdguardTeam/golibs/validate"
+	"github.com/gomodule/redigo/redis"
+)
+
+// RedisConfig is the configuration for a [Cache] that stores its elements in
+// Redis.  The cache requires Redis 6.2 or later, since it uses the GET
+// parameter of the SET command.
+type RedisConfig struct {
+	// ContextConstructor is used to create contexts for the operations with
+	// Redis, since the methods of [Cache] don't accept contexts.  The parent
+	// context is [context.Background].  If it is nil,
+	// [contextutil.EmptyConstructor] is used, so it is recommended to set it to
+	// a constructor with a timeout.
+	ContextConstructor contextutil.Constructor
+
+	// ErrorHandler handles the errors of the methods of [Cache] that can't
+	// return them.  On errors, those methods act as if the element is missing.
+	// If it is nil, [service.IgnoreErrorHandler] is used.
+	ErrorHandler service.ErrorHandler
+
+	// Clock is used to calculate the expiration times of the elements in
+	// snapshots.  If it is nil, [timeutil.SystemClock] is used.
+	Clock timeutil.Clock
+
+	// Metrics is used for the collection of the cache statistics.  If it is
+	// nil, [EmptyMetrics] is used.
+	Metrics Metrics
+
+	// Pool is used to get the connections to Redis.  It must not be nil.
+	Pool redisutil.Pool
+
+	// KeyPrefix is prepended to the keys of all elements stored in Redis.
+	// Range, DeleteFunc, Clear, Stats, and WriteTo only affect the keys with
+	// this prefix, so if it is empty, they affect all keys in the database.
+	KeyPrefix string
+
+	// TTL is the TTL of the elements added with Set.  If it is zero, those
+	// elements never expire.  It must not be negative.
+	TTL time.Duration
+
+	// MaxElementSize is the maximum size of the key and the value of a single
+	// element, in bytes.  If it is zero, the size is unlimited.
+	MaxElementSize uint
+}
+
+// redisScanCount is the number of keys requested from Redis in a single SCAN
+// or MGET command.
+const redisScanCount = 100
+
+// redisCache is a [Cache] that stores its elements in Redis.
+type redisCache struct {
+	clock       timeutil.Clock
+	contextCons contextutil.Constructor
+	errHdlr     service.ErrorHandler
+	pool        redisutil.Pool
+	counters    *counters
+
+	// prefix is prepended to the keys of the elements.
+	prefix string
+
+	// pattern is the SCAN pattern that matches the keys with prefix.
+	pattern string
+
+	ttl            time.Duration
+	maxElementSize uint
+}
+
+// type check
+var _ ttlCache = (*redisCache)(nil)
+
+// NewRedis returns a new [Cache] that stores its elements in Redis.  c must not
+// be nil and must be valid.
+//
+// Since Redis deletes the elements itself, the cache has no eviction policy,
+// and its DeleteExpired method does nothing.  Range, DeleteFunc, Clear, Stats,
+// and WriteTo scan all keys with the prefix, so they are expensive and don't
+// see a consistent state of the database.  Stats.Size is always zero.
+func NewRedis(c *RedisConfig) (rc Cache, err error) {
+	err = validate.NotNil("c", c)
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return nil, err
+	}
+
+	err = errors.Join(
+		validate.NotNilInterface("c.Pool", c.Pool),
+		validate.NotNegative("c.TTL", c.TTL),
+	)
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return nil, err
+	}
+
+	cache := &redisCache{
+		clock:          c.Clock,
+		contextCons:    c.ContextConstructor,
+		errHdlr:        c.ErrorHandler,
+		pool:           c.Pool,
+		counters:       &counters{metrics: c.Metrics},
+		prefix:         c.KeyPrefix,
+		pattern:        escapeRedisPattern(c.KeyPrefix) + "*",
+		ttl:            c.TTL,
+		maxElementSize: c.MaxElementSize,
+	}
+
+	if cache.clock == nil {
+		cache.clock = timeutil.SystemClock{}
+	}
+
+	if cache.contextCons == nil {
+		cache.contextCons = contextutil.EmptyConstructor{}
+	}
+
+	if cache.errHdlr == nil {
+		cache.errHdlr = service.IgnoreErrorHandler{}
+	}
+
+	if cache.counters.metrics == nil {
+		cache.counters.metrics = EmptyMetrics{}
+	}
+
+	return cache, nil
+}
+
+// escapeRedisPattern escapes the special characters of Redis glob-style
+// patterns in s.
+func escapeRedisPattern(s string) (pat string) {
+	b := &strings.Builder{}
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			_ = b.WriteByte('\\')
+		default:
+			// Go on.
+		}
+
+		_, _ = b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// Set implements the [Cache] interface for *redisCache.  The element expires
+// after the configured TTL.
+func (c *redisCache) Set(key, val []byte) (exists bool) {
+	return c.SetWithTTL(key, val, c.ttl)
+}
+
+// SetWithTTL implements the [Cache] interface for *redisCache.  Positive TTLs
+// less than [redisutil.MinTTL] are increased to it.
+func (c *redisCache) SetWithTTL(key, val []byte, ttl time.Duration) (exists bool) {
+	if c.maxElementSize > 0 && uint(len(key)+len(val)) > c.maxElementSize {
+		c.counters.reject(RejectionReasonTooLarge)
+
+		return false
+	}
+
+	args := []any{c.prefix + string(key), val}
+	if ttl > 0 {
+		args = append(args, redisutil.ParamPX, max(ttl, redisutil.MinTTL).Milliseconds())
+	}
+
+	args = append(args, redisutil.ParamGET)
+
+	ok := c.withConn("setting", func(conn redis.Conn) (err error) {
+		reply, err := conn.Do(redisutil.CmdSET, args...)
+		if err != nil {
+			return err
+		}
+
+		exists = reply != nil
+
+		return nil
+	})
+	if !ok {
+		return false
+	}
+
+	c.counters.set(exists)
+
+	return exists
+}
+
+// Get implements the [Cache] interface for *redisCache.
+func (c *redisCache) Get(key []byte) (val []byte) {
+	val = c.Peek(key)
+	c.counters.lookup(val != nil)
+
+	return val
+}
+
+// Del implements the [Cache] interface for *redisCache.
+func (c *redisCache) Del(key []byte) {
+	_ = c.withConn("deleting", func(conn redis.Conn) (err error) {
+		_, err = conn.Do(redisutil.CmdDEL, c.prefix+string(key))
+
+		return err
+	})
+}
+
+// Peek implements the [Cache] interface for *redisCache.
+func (c *redisCache) Peek(key []byte) (val []byte) {
+	_ = c.withConn("getting", func(conn redis.Conn) (err error) {
+		val, err = redis.Bytes(conn.Do(redisutil.CmdGET, c.prefix+string(key)))
+		if errors.Is(err, redis.ErrNil) {
+			return nil
+		}
+
+		return err
+	})
+
+	return val
+}
+
+// defaultTTL implements the [ttlCache] interface for *redisCache.
+func (c *redisCache) defaultTTL() (ttl time.Duration) {
+	return c.ttl
+}
+
+// getWithTTL implements the [ttlCache] interface for *redisCache.
+func (c *redisCache) getWithTTL(key []byte) (val []byte, ttl time.Duration) {
+	ok := c.withConn("getting", func(conn redis.Conn) (err error) {
+		val, ttl, err = redisGetWithTTL(conn, c.prefix+string(key), c.clock.Now())
+
+		return err
+	})
+	if !ok {
+		val, ttl = nil, 0
+	}
+
+	c.counters.lookup(val != nil)
+
+	return val, ttl
+}
+
+// Range implements the [Cache] interface for *redisCache.  The order of the
+// elements is unspecified.
+func (c *redisCache) Range(f func(key, val []byte) (cont bool)) {
+	var items []*item[string, []byte]
+	ok := c.withConn("ranging", func(conn redis.Conn) (err error) {
+		items, err = c.collect(conn, false)
+
+		return err
+	})
+	if !ok {
+		return
+	}
+
+	for _, it := range items {
+		if !f([]byte(it.key), it.value) {
+			return
+		}
+	}
+}
+
+// DeleteFunc implements the [Cache] interface for *redisCache.  Unlike with
+// the in-memory caches, del is called without any lock held.
+func (c *redisCache) DeleteFunc(del func(key, val []byte) (ok bool)) (n int) {
+	_ = c.withConn("deleting", func(conn redis.Conn) (err error) {
+		return c.scan(conn, func(keys []string) (err error) {
+			deleted, err := c.deleteBatch(conn, keys, del)
+			n += deleted
+
+			return err
+		})
+	})
+
+	return n
+}
+
+// deleteBatch deletes the elements with keys for which del returns true.
+func (c *redisCache) deleteBatch(
+	conn redis.Conn,
+	keys []string,
+	del func(key, val []byte) (ok bool),
+) (n int, err error) {
+	vals, err := redisGetAll(conn, keys)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, key := range keys {
+		if vals[i] == nil || !del([]byte(key[len(c.prefix):]), vals[i]) {
+			continue
+		}
+
+		var deleted int
+		deleted, err = redis.Int(conn.Do(redisutil.CmdDEL, key))
+		if err != nil {
+			return n, fmt.Errorf("deleting: %w", err)
+		}
+
+		n += deleted
+	}
+
+	return n, nil
+}
+
+// DeleteExpired implements the [Expirer] interface for *redisCache.  It does
+// nothing, since Redis deletes the expired elements itself.
+func (c *redisCache) DeleteExpired() (n int) {
+	return 0
+}
+
+// Clear implements the [Cache] interface for *redisCache.
+func (c *redisCache) Clear() {
+	_ = c.withConn("clearing", func(conn redis.Conn) (err error) {
+		return c.scan(conn, func(keys []string) (err error) {
+			_, err = conn.Do(redisutil.CmdDEL, redis.Args{}.AddFlat(keys)...)
+
+			return err
+		})
+	})
+}
+
+// Stats implements the [Cache] interface for *redisCache.
+func (c *redisCache) Stats() (s Stats) {
+	_ = c.withConn("counting", func(conn redis.Conn) (err error) {
+		return c.scan(conn, func(keys []string) (err error) {
+			s.Count += len(keys)
+
+			return nil
+		})
+	})
+
+	c.counters.fill(&s)
+
+	return s
+}
+
+// WriteTo implements the [io.WriterTo] interface for *redisCache.  The order
+// of the elements is unspecified.
+func (c *redisCache) WriteTo(w io.Writer) (n int64, err error) {
+	ctx, cancel := c.contextCons.New(context.Background())
+	defer cancel()
+
+	var items []*item[string, []byte]
+	err = c.useConn(ctx, func(conn redis.Conn) (err error) {
+		items, err = c.collect(conn, true)
+
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("collecting elements: %w", err)
+	}
+
+	return writeSnapshot(w, items)
+}
+
+// ReadFrom implements the [io.ReaderFrom] interface for *redisCache.  Unlike
+// with the in-memory caches, the errors of setting the elements are handled by
+// the error handler.
+func (c *redisCache) ReadFrom(r io.Reader) (n int64, err error) {
+	return readSnapshot(r, c.clock.Now(), func(key, val []byte, ttl time.Duration) {
+		_ = c.SetWithTTL(key, val, ttl)
+	})
+}
+
+// withConn calls f with a connection from the pool and handles the error, if
+// any, in which case ok is false.  op is used in the error message.
+func (c *redisCache) withConn(op string, f func(conn redis.Conn) (err error)) (ok bool) {
+	ctx, cancel := c.contextCons.New(context.Background())
+	defer cancel()
+
+	err := c.useConn(ctx, f)
+	if err != nil {
+		c.errHdlr.Handle(ctx, fmt.Errorf("cache: redis: %s: %w", op, err))
+
+		return false
+	}
+
+	return true
+}
+
+// useConn calls f with a connection from the pool and closes it.
+func (c *redisCache) useConn(
+	ctx context.Context,
+	f func(conn redis.Conn) (err error),
+) (err error) {
+	conn, err := c.pool.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("getting conn: %w", err)
+	}
+
+	defer func() { err = errors.WithDeferred(err, conn.Close()) }()
+
+	return f(conn)
+}
+
+// scan calls f for each batch of keys with the prefix.  The keys include the
+// prefix.
+func (c *redisCache) scan(conn redis.Conn, f func(keys []string) (err error)) (err error) {
+	cursor := "0"
+	for {
+		var keys []string
+		cursor, keys, err = c.scanPage(conn, cursor)
+		if err != nil {
+			return fmt.Errorf("scanning: %w", err)
+		}
+
+		if len(keys) > 0 {
+			err = f(keys)
+			if err != nil {
+				return err
+			}
+		}
+
+		if cursor == "0" {
+			return nil
+		}
+	}
+}
+
+// scanPage returns a single page of the keys with the prefix and the cursor of
+// the next page, which is "0" for the last page.
+func (c *redisCache) scanPage(
+	conn redis.Conn,
+	cursor string,
+) (next string, keys []string, err error) {
+	values, err := redis.Values(conn.Do(
+		redisutil.CmdSCAN,
+		cursor,
+		redisutil.ParamMATCH,
+		c.pattern,
+		redisutil.ParamCOUNT,
+		redisScanCount,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = validate.Equal("scan reply length", len(values), 2)
+	if err != nil {
+		return "", nil, err
+	}
+
+	next, err = redis.String(values[0], nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("cursor: %w", err)
+	}
+
+	keys, err = redis.Strings(values[1], nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("keys: %w", err)
+	}
+
+	return next, keys, nil
+}
+
+// collect returns all elements with the prefix.  The keys of the elements
+// don't include the prefix.  If withExpire is true, the expiration times of
+// the elements are requested as well.
+func (c *redisCache) collect(
+	conn redis.Conn,
+	withExpire bool,
+) (items []*item[string, []byte], err error) {
+	now := c.clock.Now()
+	err = c.scan(conn, func(keys []string) (err error) {
+		items, err = c.collectBatch(conn, keys, items, now, withExpire)
+
+		return err
+	})
+
+	return items, err
+}
+
+// collectBatch appends the elements with keys to items and returns the result.
+// The elements that have been deleted since the scan are skipped.
+func (c *redisCache) collectBatch(
+	conn redis.Conn,
+	keys []string,
+	items []*item[string, []byte],
+	now time.Time,
+	withExpire bool,
+) (res []*item[string, []byte], err error) {
+	vals, err := redisGetAll(conn, keys)
+	if err != nil {
+		return items, err
+	}
+
+	for i, key := range keys {
+		if vals[i] == nil {
+			continue
+		}
+
+		it := &item[string, []byte]{
+			key:   key[len(c.prefix):],
+			value: vals[i],
+		}
+
+		if withExpire {
+			var exists bool
+			it.expire, exists, err = redisExpire(conn, key, now)
+			if err != nil {
+				return items, fmt.Errorf("getting ttl of key at index %d: %w", i, err)
+			} else if !exists {
+				continue
+			}
+		}
+
+		items = append(items, it)
+	}
+
+	return items, nil
+}
+
+// redisGetAll returns the values of keys.  The values of the missing keys are
+// nil.
+func redisGetAll(conn redis.Conn, keys []string) (vals [][]byte, err error) {
+	vals, err = redis.ByteSlices(conn.Do(redisutil.CmdMGET, redis.Args{}.AddFlat(keys)...))
+	if err != nil {
+		return nil, fmt.Errorf("getting values: %w", err)
+	}
+
+	return vals, nil
+}
+
+// redisGetWithTTL returns the value and the remaining TTL of key.  val is nil
+// if the key doesn't exist, and ttl is zero if the key never expires.
+func redisGetWithTTL(
+	conn redis.Conn,
+	key string,
+	now time.Time,
+) (val []byte, ttl time.Duration, err error) {
+	val, err = redis.Bytes(conn.Do(redisutil.CmdGET, key))
+	if errors.Is(err, redis.ErrNil) {
+		return nil, 0, nil
+	} else if err != nil {
+		return nil, 0, err
+	}
+
+	expire, exists, err := redisExpire(conn, key, now)
+	switch {
+	case err != nil:
+		return nil, 0, fmt.Errorf("getting ttl: %w", err)
+	case !exists:
+		// The key has expired or has been deleted after GET.
+		return nil, 0, nil
+	case expire.IsZero():
+		return val, 0, nil
+	case !expire.After(now):
+		// The key is about to expire.
+		return nil, 0, nil
+	default:
+		return val, expire.Sub(now), nil
+	}
+}
+
+// redisExpire returns the expiration time of key.  expire is zero if the key
+// never expires.  exists is false if the key has been deleted.
+func redisExpire(
+	conn redis.Conn,
+	key string,
+	now time.Time,
+) (expire time.Time, exists bool, err error) {
+	pttl, err := redis.Int64(conn.Do(redisutil.CmdPTTL, key))
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	switch {
+	case pttl == -2:
+		// The key doesn't exist.
+		return time.Time{}, false, nil
+	case pttl < 0:
+		// The key never expires.
+		return time.Time{}, true, nil
+	default:
+		return now.Add(time.Duration(pttl) * time.Millisecond), true, nil
+	}
+}
@@ -0,0 +1,355 @@
+package cache_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AdguardTeam/golibs/cache"
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/AdguardTeam/golibs/redisutil"
+	"github.com/AdguardTeam/golibs/service"
+	"github.com/AdguardTeam/golibs/testutil"
+	"github.com/AdguardTeam/golibs/testutil/fakeredis"
+	"github.com/AdguardTeam/golibs/testutil/redistest"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testKeyPrefix is the common key prefix for tests.
+const testKeyPrefix = "test:"
+
+// fakeRedis is an in-memory emulation of the Redis commands used by the cache.
+type fakeRedis struct {
+	now  *time.Time
+	mu   *sync.Mutex
+	data map[string]fakeRedisEntry
+}
+
+// fakeRedisEntry is an entry of a [fakeRedis].
+type fakeRedisEntry struct {
+	expire time.Time
+	val    []byte
+}
+
+// newFakeRedis returns a new *fakeRedis that uses *now as the current time
+// and a pool that uses it.
+func newFakeRedis(now *time.Time) (r *fakeRedis, p *fakeredis.Pool) {
+	r = &fakeRedis{
+		now:  now,
+		mu:   &sync.Mutex{},
+		data: map[string]fakeRedisEntry{},
+	}
+
+	p = &fakeredis.Pool{
+		OnClose: func() (err error) { panic(testutil.UnexpectedCall()) },
+		OnGet: func(_ context.Context) (c redis.Conn, err error) {
+			conn := fakeredis.NewConn()
+			conn.OnDo = r.do
+			conn.OnClose = func() (err error) { return nil }
+
+			return conn, nil
+		},
+	}
+
+	return r, p
+}
+
+// fakeArgString converts a command argument to a string.
+func fakeArgString(arg any) (s string) {
+	switch arg := arg.(type) {
+	case []byte:
+		return string(arg)
+	default:
+		return fmt.Sprint(arg)
+	}
+}
+
+// do implements the Do method of a [redis.Conn].
+func (r *fakeRedis) do(cmdName string, args ...any) (reply any, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	strs := make([]string, 0, len(args))
+	for _, a := range args {
+		strs = append(strs, fakeArgString(a))
+	}
+
+	r.deleteExpired()
+
+	switch cmdName {
+	case redisutil.CmdGET:
+		return r.get(strs[0]), nil
+	case redisutil.CmdMGET:
+		vals := make([]any, 0, len(strs))
+		for _, k := range strs {
+			vals = append(vals, r.get(k))
+		}
+
+		return vals, nil
+	case redisutil.CmdSET:
+		return r.set(strs)
+	case redisutil.CmdDEL:
+		return r.del(strs), nil
+	case redisutil.CmdPTTL:
+		return r.pttl(strs[0]), nil
+	case redisutil.CmdSCAN:
+		return r.scan(strs[2])
+	default:
+		return nil, fmt.Errorf("unexpected command %q", cmdName)
+	}
+}
+
+// deleteExpired deletes the expired entries.
+func (r *fakeRedis) deleteExpired() {
+	for k, e := range r.data {
+		if !e.expire.IsZero() && !r.now.Before(e.expire) {
+			delete(r.data, k)
+		}
+	}
+}
+
+// del emulates the DEL command.
+func (r *fakeRedis) del(keys []string) (n int64) {
+	for _, k := range keys {
+		if _, ok := r.data[k]; ok {
+			delete(r.data, k)
+			n++
+		}
+	}
+
+	return n
+}
+
+// get returns the value of key or nil if there is none.
+func (r *fakeRedis) get(key string) (val any) {
+	e, ok := r.data[key]
+	if !ok {
+		return nil
+	}
+
+	return e.val
+}
+
+// set emulates the SET command with the optional PX and GET parameters.
+func (r *fakeRedis) set(args []string) (reply any, err error) {
+	key := args[0]
+	e := fakeRedisEntry{
+		val: []byte(args[1]),
+	}
+
+	withGet := false
+	for i := 2; i < len(args); i++ {
+		switch args[i] {
+		case redisutil.ParamPX:
+			i++
+			ms, _ := strconv.ParseInt(args[i], 10, 64)
+			e.expire = r.now.Add(time.Duration(ms) * time.Millisecond)
+		case redisutil.ParamGET:
+			withGet = true
+		default:
+			return nil, fmt.Errorf("unexpected parameter %q", args[i])
+		}
+	}
+
+	reply = redisutil.RespOK
+	if withGet {
+		reply = r.get(key)
+	}
+
+	r.data[key] = e
+
+	return reply, nil
+}
+
+// pttl emulates the PTTL command.
+func (r *fakeRedis) pttl(key string) (ms int64) {
+	e, ok := r.data[key]
+	if !ok {
+		return -2
+	} else if e.expire.IsZero() {
+		return -1
+	}
+
+	return e.expire.Sub(*r.now).Milliseconds()
+}
+
+// scan emulates the SCAN command by returning all matching keys at once.
+func (r *fakeRedis) scan(pattern string) (reply any, err error) {
+	var keys []string
+	for k := range r.data {
+		ok, matchErr := path.Match(pattern, k)
+		if matchErr != nil {
+			return nil, matchErr
+		} else if ok {
+			keys = append(keys, k)
+		}
+	}
+
+	slices.Sort(keys)
+
+	keyReplies := make([]any, 0, len(keys))
+	for _, k := range keys {
+		keyReplies = append(keyReplies, []byte(k))
+	}
+
+	return []any{[]byte("0"), keyReplies}, nil
+}
+
+// newTestRedis returns a new Redis cache for tests, which uses p.
+func newTestRedis(tb testing.TB, now *time.Time, p redisutil.Pool) (c cache.Cache) {
+	tb.Helper()
+
+	c, err := cache.NewRedis(&cache.RedisConfig{
+		Clock:     newTestClock(now),
+		Pool:      p,
+		KeyPrefix: testKeyPrefix,
+		TTL:       testTTL,
+	})
+	require.NoError(tb, err)
+
+	return c
+}
+
+func TestNewRedis(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	r, p := newFakeRedis(&now)
+	c := newTestRedis(t, &now, p)
+
+	assert.False(t, c.Set([]byte("k1"), []byte("v1")))
+	assert.True(t, c.Set([]byte("k1"), []byte("v1")))
+	assert.False(t, c.SetWithTTL([]byte("k2"), []byte("v2"), 0))
+
+	assert.Equal(t, []byte("v1"), c.Get([]byte("k1")))
+	assert.Equal(t, []byte("v2"), c.Peek([]byte("k2")))
+	assert.Nil(t, c.Get([]byte("k3")))
+
+	assert.Contains(t, r.data, testKeyPrefix+"k1")
+
+	s := c.Stats()
+	assert.Equal(t, 2, s.Count)
+	assert.Equal(t, uint64(1), s.Hit)
+	assert.Equal(t, uint64(1), s.Miss)
+	assert.Equal(t, uint64(1), s.Overwrite)
+
+	// Set uses the configured TTL.
+	now = now.Add(testTTL)
+	assert.Nil(t, c.Get([]byte("k1")))
+	assert.Equal(t, []byte("v2"), c.Get([]byte("k2")))
+
+	c.Del([]byte("k2"))
+	assert.Nil(t, c.Get([]byte("k2")))
+}
+
+func TestNewRedis_scan(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	r, p := newFakeRedis(&now)
+	c := newTestRedis(t, &now, p)
+
+	// The keys without the prefix must not be affected.
+	const otherKey = "other:k1"
+	r.data[otherKey] = fakeRedisEntry{val: []byte("other")}
+
+	require.False(t, c.Set([]byte("k1"), []byte("v1")))
+	require.False(t, c.SetWithTTL([]byte("k2"), []byte("v2"), 0))
+	require.False(t, c.Set([]byte("k3"), []byte("v3")))
+
+	var keys []string
+	c.Range(func(key, _ []byte) (cont bool) {
+		keys = append(keys, string(key))
+
+		return true
+	})
+	assert.Equal(t, []string{"k1", "k2", "k3"}, keys)
+
+	n := c.DeleteFunc(func(key, _ []byte) (ok bool) {
+		return string(key) == "k3"
+	})
+	assert.Equal(t, 1, n)
+	assert.Nil(t, c.Get([]byte("k3")))
+
+	buf := &bytes.Buffer{}
+	_, err := c.WriteTo(buf)
+	require.NoError(t, err)
+
+	c.Clear()
+	assert.Zero(t, c.Stats().Count)
+	assert.Contains(t, r.data, otherKey)
+
+	_, err = c.ReadFrom(buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("v1"), c.Get([]byte("k1")))
+	assert.Equal(t, []byte("v2"), c.Get([]byte("k2")))
+
+	now = now.Add(testTTL)
+	assert.Nil(t, c.Get([]byte("k1")))
+	assert.Equal(t, []byte("v2"), c.Get([]byte("k2")))
+}
+
+func TestNewRedis_error(t *testing.T) {
+	t.Parallel()
+
+	const testError errors.Error = "test error"
+
+	var gotErr error
+	c, err := cache.NewRedis(&cache.RedisConfig{
+		ErrorHandler: service.ErrorHandlerFunc(func(_ context.Context, err error) {
+			gotErr = err
+		}),
+		Pool: &fakeredis.Pool{
+			OnClose: func() (err error) { panic(testutil.UnexpectedCall()) },
+			OnGet: func(_ context.Context) (conn redis.Conn, err error) {
+				return nil, testError
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Nil(t, c.Get([]byte("k1")))
+	testutil.AssertErrorMsg(t, "cache: redis: getting: getting conn: test error", gotErr)
+
+	assert.False(t, c.Set([]byte("k1"), []byte("v1")))
+	testutil.AssertErrorMsg(t, "cache: redis: setting: getting conn: test error", gotErr)
+
+	_, err = c.WriteTo(&bytes.Buffer{})
+	testutil.AssertErrorMsg(t, "collecting elements: getting conn: test error", err)
+
+	_, err = cache.NewRedis(&cache.RedisConfig{
+		TTL: -1,
+	})
+	require.Error(t, err)
+
+	assert.ErrorContains(t, err, "c.Pool")
+	assert.ErrorContains(t, err, "c.TTL")
+}
+
+func TestNewRedis_integration(t *testing.T) {
+	p := redistest.NewPool(t, nil)
+
+	c, err := cache.NewRedis(&cache.RedisConfig{
+		Pool:      p,
+		KeyPrefix: testKeyPrefix,
+		TTL:       testTTL,
+	})
+	require.NoError(t, err)
+
+	assert.False(t, c.Set([]byte("k1"), []byte("v1")))
+	assert.True(t, c.Set([]byte("k1"), []byte("v1")))
+	assert.Equal(t, []byte("v1"), c.Get([]byte("k1")))
+	assert.Equal(t, 1, c.Stats().Count)
+
+	c.Clear()
+	assert.Nil(t, c.Get([]byte("k1")))
+}
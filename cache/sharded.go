@@ -0,0 +1,134 @@
+package cache
+
+import (
+	"hash/maphash"
+	"time"
+)
+
+// shardedCache is a cache that splits its elements between several
+// independently locked shards to reduce lock contention.
+type shardedCache[K comparable, V any] struct {
+	seed   maphash.Seed
+	shards []*typedCache[K, V]
+}
+
+// newShardedCache returns a new sharded cache with n shards.  The limits of
+// conf are split evenly between the shards.  n must be greater than one.
+func newShardedCache[K comparable, V any](conf TypedConfig[K, V], n uint) (c *shardedCache[K, V]) {
+	shardConf := conf
+	shardConf.MaxSize = divCeil(conf.MaxSize, n)
+	shardConf.MaxCount = divCeil(conf.MaxCount, n)
+
+	c = &shardedCache[K, V]{
+		seed:   maphash.MakeSeed(),
+		shards: make([]*typedCache[K, V], n),
+	}
+
+	for i := range c.shards {
+		c.shards[i] = newTypedCache(shardConf)
+	}
+
+	return c
+}
+
+// divCeil returns a divided by b rounded up.  b must not be zero.
+func divCeil(a, b uint) (res uint) {
+	res = a / b
+	if a%b != 0 {
+		res++
+	}
+
+	return res
+}
+
+// shard returns the shard for key.
+func (c *shardedCache[K, V]) shard(key K) (s *typedCache[K, V]) {
+	h := maphash.Comparable(c.seed, key)
+
+	return c.shards[h%uint64(len(c.shards))]
+}
+
+// type check
+var _ internalTyped[string, []byte] = (*shardedCache[string, []byte])(nil)
+
+// Set implements the [Typed] interface for *shardedCache.
+func (c *shardedCache[K, V]) Set(key K, val V) (replaced bool) {
+	return c.shard(key).Set(key, val)
+}
+
+// SetWithTTL implements the [Typed] interface for *shardedCache.
+func (c *shardedCache[K, V]) SetWithTTL(key K, val V, ttl time.Duration) (replaced bool) {
+	return c.shard(key).SetWithTTL(key, val, ttl)
+}
+
+// Get implements the [Typed] interface for *shardedCache.
+func (c *shardedCache[K, V]) Get(key K) (val V, ok bool) {
+	return c.shard(key).Get(key)
+}
+
+// Peek implements the [Typed] interface for *shardedCache.
+func (c *shardedCache[K, V]) Peek(key K) (val V, ok bool) {
+	return c.shard(key).Peek(key)
+}
+
+// Range implements the [Typed] interface for *shardedCache.  The elements of
+// each shard are visited in their order, one shard after another.
+func (c *shardedCache[K, V]) Range(f func(key K, val V) (cont bool)) {
+	for _, it := range c.snapshot() {
+		if !f(it.key, it.value) {
+			return
+		}
+	}
+}
+
+// DeleteFunc implements the [Typed] interface for *shardedCache.  Each shard
+// is locked separately.
+func (c *shardedCache[K, V]) DeleteFunc(del func(key K, val V) (ok bool)) (n int) {
+	for _, s := range c.shards {
+		n += s.DeleteFunc(del)
+	}
+
+	return n
+}
+
+// Del implements the [Typed] interface for *shardedCache.
+func (c *shardedCache[K, V]) Del(key K) {
+	c.shard(key).Del(key)
+}
+
+// DeleteExpired implements the [Typed] interface for *shardedCache.
+func (c *shardedCache[K, V]) DeleteExpired() (n int) {
+	for _, s := range c.shards {
+		n += s.DeleteExpired()
+	}
+
+	return n
+}
+
+// snapshot implements the [internalTyped] interface for *shardedCache.  The
+// items of each shard are kept in their order.
+func (c *shardedCache[K, V]) snapshot() (items []*item[K, V]) {
+	for _, s := range c.shards {
+		items = append(items, s.snapshot()...)
+	}
+
+	return items
+}
+
+// Clear implements the [Typed] interface for *shardedCache.
+func (c *shardedCache[K, V]) Clear() {
+	for _, s := range c.shards {
+		s.Clear()
+	}
+}
+
+// Stats implements the [Typed] interface for *shardedCache.  The statistics
+// are the sums of the statistics of all shards.
+func (c *shardedCache[K, V]) Stats() (s Stats) {
+	for _, shard := range c.shards {
+		ss := shard.Stats()
+		s.add(&ss)
+	}
+
+	return s
+}
@@ -0,0 +1,161 @@
+package cache_test
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_sharded(t *testing.T) {
+	t.Parallel()
+
+	const (
+		shardCount = 4
+		keyCount   = 100
+	)
+
+	c := cache.New(cache.Config{
+		MaxCount:   keyCount,
+		EnableLRU:  true,
+		ShardCount: shardCount,
+	})
+
+	for i := range keyCount / 2 {
+		key := []byte(strconv.Itoa(i))
+		require.False(t, c.Set(key, key))
+	}
+
+	require.True(t, c.Set([]byte("0"), []byte("zero")))
+
+	assert.Equal(t, []byte("zero"), c.Get([]byte("0")))
+	assert.Equal(t, []byte("1"), c.Get([]byte("1")))
+	assert.Nil(t, c.Get([]byte("absent")))
+
+	c.Del([]byte("1"))
+	assert.Nil(t, c.Get([]byte("1")))
+
+	assert.Equal(t, cache.Stats{
+		Count:     keyCount/2 - 1,
+		Size:      2*(10+2*40) - 2 + len("zero") - 1,
+		Hit:       2,
+		Miss:      2,
+		Overwrite: 1,
+	}, c.Stats())
+
+	c.Clear()
+	assert.Zero(t, c.Stats().Count)
+	assert.Zero(t, c.Stats().Size)
+}
+
+func TestNew_shardedLimits(t *testing.T) {
+	t.Parallel()
+
+	const shardCount = 4
+
+	c := cache.New(cache.Config{
+		MaxCount:   1,
+		EnableLRU:  true,
+		ShardCount: shardCount,
+	})
+
+	for i := range 100 {
+		key := []byte(strconv.Itoa(i))
+		_ = c.Set(key, key)
+	}
+
+	// The limit is rounded up, so that every shard can hold at least one
+	// element.
+	assert.LessOrEqual(t, c.Stats().Count, shardCount)
+}
+
+func TestNew_shardedParallel(t *testing.T) {
+	t.Parallel()
+
+	c := cache.New(cache.Config{
+		MaxSize:    1024,
+		EnableLRU:  true,
+		ShardCount: 8,
+	})
+
+	wg := &sync.WaitGroup{}
+	for w := range 10 {
+		wg.Go(func() {
+			for i := range 100 {
+				key := fmt.Appendf(nil, "key-%d-%d", w, i)
+				val := []byte{1, 2, 3, byte(i)}
+				_ = c.Set(key, val)
+
+				rval := c.Get(key)
+				if rval != nil {
+					assert.Equal(t, val, rval)
+				}
+
+				c.Del(key)
+			}
+		})
+	}
+
+	wg.Wait()
+}
+
+func BenchmarkCache_parallel(b *testing.B) {
+	const keyCount = 1 << 12
+
+	keys := make([][]byte, keyCount)
+	for i := range keys {
+		keys[i] = fmt.Appendf(nil, "www.example-%d.com", i)
+	}
+
+	val := make([]byte, 64)
+
+	for _, shardCount := range []uint{1, 16} {
+		b.Run(fmt.Sprintf("%d_shards", shardCount), func(b *testing.B) {
+			c := cache.New(cache.Config{
+				MaxCount:   keyCount,
+				EnableLRU:  true,
+				ShardCount: shardCount,
+			})
+
+			for _, k := range keys {
+				_ = c.Set(k, val)
+			}
+
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				benchmarkMostlyGet(pb, c, keys, val)
+			})
+		})
+	}
+
+	// Most recent results:
+	//
+	// NOTE:  The machine only has one CPU, so the lock contention is not
+	// visible here and the results only show the overhead of the hashing.
+	//
+	//	goos: linux
+	//	goarch: amd64
+	//	pkg: github.com/AdguardTeam/golibs/cache
+	//	cpu: Intel(R) Xeon(R) Processor
+	//	BenchmarkCache_parallel/1_shards           	10541931	       114.8 ns/op	      13 B/op	       0 allocs/op
+	//	BenchmarkCache_parallel/1_shards-4         	 8109906	       161.7 ns/op	      13 B/op	       0 allocs/op
+	//	BenchmarkCache_parallel/16_shards          	 7571071	       149.2 ns/op	      13 B/op	       0 allocs/op
+	//	BenchmarkCache_parallel/16_shards-4        	 6916755	       170.8 ns/op	      13 B/op	       0 allocs/op
+}
+
+// benchmarkMostlyGet is a helper for parallel benchmarks that mostly gets the
+// values of keys from c and sometimes sets them to val.
+func benchmarkMostlyGet(pb *testing.PB, c cache.Cache, keys [][]byte, val []byte) {
+	for i := 0; pb.Next(); i++ {
+		k := keys[i%len(keys)]
+		if i%8 == 0 {
+			_ = c.Set(k, val)
+		} else {
+			_ = c.Get(k)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"hash/maphash"
+	"math/bits"
+)
+
+// Count-min sketch parameters.
+const (
+	// sketchDepth is the number of rows of the sketch.
+	sketchDepth = 4
+
+	// sketchMaxWidth is the maximum number of counters in a row.
+	sketchMaxWidth = 1 << 22
+
+	// sketchDefaultWidth is the number of counters in a row when the number of
+	// items is not limited.
+	sketchDefaultWidth = 1 << 18
+
+	// sketchWidthFactor is the number of counters in a row per item.  Together
+	// with sketchSampleFactor, it makes each counter be incremented about two
+	// or three times between agings, which keeps the error low.
+	sketchWidthFactor = 4
+
+	// sketchMaxFreq is the maximum value of a counter.
+	sketchMaxFreq = 15
+
+	// sketchSampleFactor is the number of increments per item after which all
+	// counters are halved.
+	sketchSampleFactor = 10
+)
+
+// frequencySketch is a count-min sketch that estimates the frequencies of keys
+// within a sliding time window.
+type frequencySketch[K comparable] struct {
+	seed     maphash.Seed
+	counters []uint8
+
+	// mask is used to get an index within a row.
+	mask uint64
+
+	// additions is the number of increments since the last aging.
+	additions uint
+
+	// sampleSize is the number of increments after which the sketch is aged.
+	sampleSize uint
+}
+
+// newFrequencySketch returns a new properly initialized *frequencySketch
+// suitable for a cache with at most maxCount items.
+func newFrequencySketch[K comparable](maxCount uint) (s *frequencySketch[K]) {
+	n := uint(sketchDefaultWidth / sketchWidthFactor)
+	if maxCount != maxUint {
+		n = min(max(maxCount, 16), sketchMaxWidth/sketchWidthFactor)
+		n = 1 << bits.Len(n-1)
+	}
+
+	width := sketchWidthFactor * n
+
+	return &frequencySketch[K]{
+		seed:       maphash.MakeSeed(),
+		counters:   make([]uint8, sketchDepth*width),
+		mask:       uint64(width - 1),
+		sampleSize: sketchSampleFactor * n,
+	}
+}
+
+// indexes returns the indexes of the counters for key in each row.
+func (s *frequencySketch[K]) indexes(key K) (idxs [sketchDepth]uint64) {
+	h := maphash.Comparable(s.seed, key)
+	h1, h2 := h&0xffff_ffff, h>>32|1
+
+	width := s.mask + 1
+	for i := range idxs {
+		idxs[i] = uint64(i)*width + (h1+uint64(i)*h2)&s.mask
+	}
+
+	return idxs
+}
+
+// increment increments the estimated frequency of key.
+func (s *frequencySketch[K]) increment(key K) {
+	added := false
+	for _, idx := range s.indexes(key) {
+		if s.counters[idx] < sketchMaxFreq {
+			s.counters[idx]++
+			added = true
+		}
+	}
+
+	if !added {
+		return
+	}
+
+	s.additions++
+	if s.additions >= s.sampleSize {
+		s.age()
+	}
+}
+
+// estimate returns the estimated frequency of key.
+func (s *frequencySketch[K]) estimate(key K) (freq uint8) {
+	freq = sketchMaxFreq
+	for _, idx := range s.indexes(key) {
+		freq = min(freq, s.counters[idx])
+	}
+
+	return freq
+}
+
+// age halves all counters, so that old uses matter less than the new ones.
+func (s *frequencySketch[K]) age() {
+	for i, c := range s.counters {
+		s.counters[i] = c / 2
+	}
+
+	s.additions /= 2
+}
+
+// reset resets all counters.
+func (s *frequencySketch[K]) reset() {
+	clear(s.counters)
+	s.additions = 0
+}
@@ -0,0 +1,294 @@
+package cache
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/AdguardTeam/golibs/errors"
+)
+
+// ErrBadSnapshot is returned by [Cache.ReadFrom] when the data is not a valid
+// snapshot.
+const ErrBadSnapshot errors.Error = "bad snapshot"
+
+// Snapshot format.  A snapshot consists of a header followed by the entries.
+//
+// The header consists of:
+//   - 4 bytes of snapshotMagic;
+//   - 1 byte of the version, which is snapshotVersion;
+//   - the number of entries as an unsigned varint.
+//
+// Each entry consists of:
+//   - the length of the key as an unsigned varint;
+//   - the key;
+//   - the length of the value as an unsigned varint;
+//   - the value;
+//   - the expiration time as a varint number of nanoseconds since the Unix
+//     epoch or zero if the entry never expires.
+const (
+	snapshotMagic           = "GLCS"
+	snapshotVersion   uint8 = 1
+	snapshotHeaderLen       = len(snapshotMagic) + 1
+)
+
+// countingWriter is an [io.Writer] that counts the bytes written.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// type check
+var _ io.Writer = (*countingWriter)(nil)
+
+// Write implements the [io.Writer] interface for *countingWriter.
+func (w *countingWriter) Write(b []byte) (n int, err error) {
+	n, err = w.w.Write(b)
+	w.n += int64(n)
+
+	return n, err
+}
+
+// countingReader is an [io.Reader] that counts the bytes read.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// type check
+var _ io.Reader = (*countingReader)(nil)
+
+// Read implements the [io.Reader] interface for *countingReader.
+func (r *countingReader) Read(b []byte) (n int, err error) {
+	n, err = r.r.Read(b)
+	r.n += int64(n)
+
+	return n, err
+}
+
+// snapshotWriter writes the snapshot data.
+type snapshotWriter struct {
+	w   *bufio.Writer
+	buf [binary.MaxVarintLen64]byte
+}
+
+// writeUvarint writes v as an unsigned varint.
+func (sw *snapshotWriter) writeUvarint(v uint64) (err error) {
+	_, err = sw.w.Write(binary.AppendUvarint(sw.buf[:0], v))
+
+	return err
+}
+
+// writeBytes writes the length of b followed by b.
+func (sw *snapshotWriter) writeBytes(b []byte) (err error) {
+	err = sw.writeUvarint(uint64(len(b)))
+	if err != nil {
+		return err
+	}
+
+	_, err = sw.w.Write(b)
+
+	return err
+}
+
+// writeString is like writeBytes but for strings.
+func (sw *snapshotWriter) writeString(s string) (err error) {
+	err = sw.writeUvarint(uint64(len(s)))
+	if err != nil {
+		return err
+	}
+
+	_, err = sw.w.WriteString(s)
+
+	return err
+}
+
+// writeEntry writes a single entry.
+func (sw *snapshotWriter) writeEntry(it *item[string, []byte]) (err error) {
+	err = sw.writeString(it.key)
+	if err != nil {
+		return fmt.Errorf("writing key: %w", err)
+	}
+
+	err = sw.writeBytes(it.value)
+	if err != nil {
+		return fmt.Errorf("writing value: %w", err)
+	}
+
+	var expire int64
+	if !it.expire.IsZero() {
+		expire = it.expire.UnixNano()
+	}
+
+	_, err = sw.w.Write(binary.AppendVarint(sw.buf[:0], expire))
+	if err != nil {
+		return fmt.Errorf("writing expiration: %w", err)
+	}
+
+	return nil
+}
+
+// WriteTo implements the [io.WriterTo] interface for *bytesCache.
+func (c *bytesCache) WriteTo(w io.Writer) (n int64, err error) {
+	return writeSnapshot(w, c.typed.snapshot())
+}
+
+// writeSnapshot writes a snapshot of items to w.
+func writeSnapshot(w io.Writer, items []*item[string, []byte]) (n int64, err error) {
+	cw := &countingWriter{w: w}
+	sw := &snapshotWriter{w: bufio.NewWriter(cw)}
+
+	// The errors of a bufio.Writer are sticky, so only check the last one.
+	_, _ = sw.w.WriteString(snapshotMagic)
+	_ = sw.w.WriteByte(snapshotVersion)
+	err = sw.writeUvarint(uint64(len(items)))
+	if err != nil {
+		return cw.n, fmt.Errorf("writing header: %w", err)
+	}
+
+	for i, it := range items {
+		err = sw.writeEntry(it)
+		if err != nil {
+			return cw.n, fmt.Errorf("entry at index %d: %w", i, err)
+		}
+	}
+
+	err = sw.w.Flush()
+	if err != nil {
+		return cw.n, fmt.Errorf("flushing: %w", err)
+	}
+
+	return cw.n, nil
+}
+
+// ReadFrom implements the [io.ReaderFrom] interface for *bytesCache.
+func (c *bytesCache) ReadFrom(r io.Reader) (n int64, err error) {
+	return readSnapshot(r, c.clock.Now(), func(key, val []byte, ttl time.Duration) {
+		_ = c.typed.SetWithTTL(string(key), val, ttl)
+	})
+}
+
+// snapshotSetFunc adds an entry read from a snapshot.  ttl is not positive if
+// the entry never expires.
+type snapshotSetFunc func(key, val []byte, ttl time.Duration)
+
+// readSnapshot reads a snapshot from r and calls set for each entry that hasn't
+// expired by now and isn't too large.
+func readSnapshot(r io.Reader, now time.Time, set snapshotSetFunc) (n int64, err error) {
+	cr := &countingReader{r: r}
+	br := bufio.NewReader(cr)
+
+	num, err := readSnapshotHeader(br)
+	if err != nil {
+		return cr.n, fmt.Errorf("reading header: %w", err)
+	}
+
+	for i := range num {
+		err = readEntry(br, now, set)
+		if err != nil {
+			return cr.n, fmt.Errorf("entry at index %d: %w", i, err)
+		}
+	}
+
+	return cr.n, nil
+}
+
+// readSnapshotHeader reads and validates the snapshot header and returns the
+// number of entries.
+func readSnapshotHeader(br *bufio.Reader) (num uint64, err error) {
+	hdr := make([]byte, snapshotHeaderLen)
+	_, err = io.ReadFull(br, hdr)
+	if err != nil {
+		return 0, noEOF(err)
+	}
+
+	if string(hdr[:len(snapshotMagic)]) != snapshotMagic {
+		return 0, fmt.Errorf("%w: bad magic %q", ErrBadSnapshot, hdr[:len(snapshotMagic)])
+	}
+
+	v := hdr[len(snapshotMagic)]
+	if v != snapshotVersion {
+		return 0, fmt.Errorf("%w: unsupported version %d", ErrBadSnapshot, v)
+	}
+
+	num, err = binary.ReadUvarint(br)
+	if err != nil {
+		return 0, fmt.Errorf("number of entries: %w", noEOF(err))
+	}
+
+	return num, nil
+}
+
+// readEntry reads a single entry and calls set for it unless it has expired by
+// now or is too large.
+func readEntry(br *bufio.Reader, now time.Time, set snapshotSetFunc) (err error) {
+	key, keyOK, err := readSnapshotBytes(br)
+	if err != nil {
+		return fmt.Errorf("reading key: %w", noEOF(err))
+	}
+
+	val, valOK, err := readSnapshotBytes(br)
+	if err != nil {
+		return fmt.Errorf("reading value: %w", noEOF(err))
+	}
+
+	expire, err := binary.ReadVarint(br)
+	if err != nil {
+		return fmt.Errorf("reading expiration: %w", noEOF(err))
+	}
+
+	if !keyOK || !valOK {
+		return nil
+	}
+
+	var ttl time.Duration
+	if expire != 0 {
+		ttl = time.Unix(0, expire).Sub(now)
+		if ttl <= 0 {
+			return nil
+		}
+	}
+
+	set(key, val, ttl)
+
+	return nil
+}
+
+// snapshotMaxLen is the maximum length of a key or a value in a snapshot that
+// is read into memory.  Longer data is skipped.
+const snapshotMaxLen = 1 << 24
+
+// readSnapshotBytes reads the length of the data followed by the data.  If the
+// data is longer than snapshotMaxLen, it is skipped and ok is false.
+func readSnapshotBytes(br *bufio.Reader) (b []byte, ok bool, err error) {
+	l, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if l > snapshotMaxLen {
+		_, err = br.Discard(int(min(l, uint64(maxInt))))
+
+		return nil, false, err
+	}
+
+	b = make([]byte, l)
+	_, err = io.ReadFull(br, b)
+
+	return b, true, err
+}
+
+// maxInt is the maximum value of int.
+const maxInt = int(maxUint >> 1)
+
+// noEOF replaces [io.EOF] with [io.ErrUnexpectedEOF], since a snapshot must
+// not end unexpectedly.
+func noEOF(err error) (res error) {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
@@ -0,0 +1,182 @@
+package cache_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/AdguardTeam/golibs/cache"
+	"github.com/AdguardTeam/golibs/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_WriteTo(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	c := cache.New(cache.Config{
+		Clock:     newTestClock(&now),
+		EnableLRU: true,
+	})
+
+	require.False(t, c.Set([]byte("k1"), []byte("v1")))
+	require.False(t, c.SetWithTTL([]byte("k2"), []byte("v2"), 1))
+	require.False(t, c.SetWithTTL([]byte("k3"), []byte(""), 2))
+
+	// Make k1 the most recently used element.
+	require.NotNil(t, c.Get([]byte("k1")))
+
+	buf := &bytes.Buffer{}
+	n, err := c.WriteTo(buf)
+	require.NoError(t, err)
+
+	want := []byte{
+		'G', 'L', 'C', 'S',
+		1,
+		3,
+		2, 'k', '2', 2, 'v', '2', 2,
+		2, 'k', '3', 0, 4,
+		2, 'k', '1', 2, 'v', '1', 0,
+	}
+
+	assert.Equal(t, want, buf.Bytes())
+	assert.Equal(t, int64(len(want)), n)
+}
+
+func TestCache_ReadFrom(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	src := cache.New(cache.Config{
+		Clock:     newTestClock(&now),
+		EnableLRU: true,
+	})
+
+	require.False(t, src.Set([]byte("k1"), []byte("v1")))
+	require.False(t, src.Set([]byte("k2"), []byte("v2")))
+	require.False(t, src.SetWithTTL([]byte("k3"), []byte("v3"), testTTL))
+	require.False(t, src.SetWithTTL([]byte("k4"), []byte("v4"), 2*testTTL))
+	require.NotNil(t, src.Get([]byte("k1")))
+
+	buf := &bytes.Buffer{}
+	_, err := src.WriteTo(buf)
+	require.NoError(t, err)
+
+	data := buf.Bytes()
+
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+
+		dstNow := now
+		dst := cache.New(cache.Config{
+			Clock:      newTestClock(&dstNow),
+			EnableLRU:  true,
+			ShardCount: 2,
+		})
+
+		n, rErr := dst.ReadFrom(bytes.NewReader(data))
+		require.NoError(t, rErr)
+
+		assert.Equal(t, int64(len(data)), n)
+		assert.Equal(t, 4, dst.Stats().Count)
+
+		dstNow = dstNow.Add(testTTL)
+		assert.Nil(t, dst.Get([]byte("k3")))
+		assert.Equal(t, []byte("v4"), dst.Get([]byte("k4")))
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		t.Parallel()
+
+		dst := cache.New(cache.Config{
+			Clock:     newTestClock(&now),
+			MaxCount:  2,
+			EnableLRU: true,
+		})
+
+		_, rErr := dst.ReadFrom(bytes.NewReader(data))
+		require.NoError(t, rErr)
+
+		// The least recently used elements are deleted.
+		assert.Nil(t, dst.Get([]byte("k2")))
+		assert.Nil(t, dst.Get([]byte("k3")))
+		assert.Equal(t, []byte("v4"), dst.Get([]byte("k4")))
+		assert.Equal(t, []byte("v1"), dst.Get([]byte("k1")))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+
+		dstNow := now.Add(testTTL)
+		dst := cache.New(cache.Config{
+			Clock: newTestClock(&dstNow),
+		})
+
+		_, rErr := dst.ReadFrom(bytes.NewReader(data))
+		require.NoError(t, rErr)
+
+		assert.Equal(t, 3, dst.Stats().Count)
+		assert.Nil(t, dst.Get([]byte("k3")))
+	})
+}
+
+func TestCache_ReadFrom_bad(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		wantErrMsg string
+		in         []byte
+	}{{
+		name:       "empty",
+		wantErrMsg: "reading header: unexpected EOF",
+		in:         []byte{},
+	}, {
+		name:       "bad_magic",
+		wantErrMsg: `reading header: bad snapshot: bad magic "ABCD"`,
+		in:         []byte{'A', 'B', 'C', 'D', 1, 0},
+	}, {
+		name:       "bad_version",
+		wantErrMsg: "reading header: bad snapshot: unsupported version 2",
+		in:         []byte{'G', 'L', 'C', 'S', 2, 0},
+	}, {
+		name:       "no_number",
+		wantErrMsg: "reading header: number of entries: unexpected EOF",
+		in:         []byte{'G', 'L', 'C', 'S', 1},
+	}, {
+		name:       "no_entry",
+		wantErrMsg: "entry at index 0: reading key: unexpected EOF",
+		in:         []byte{'G', 'L', 'C', 'S', 1, 1},
+	}, {
+		name:       "short_value",
+		wantErrMsg: "entry at index 0: reading value: unexpected EOF",
+		in:         []byte{'G', 'L', 'C', 'S', 1, 1, 1, 'k', 2, 'v'},
+	}, {
+		name:       "no_expiration",
+		wantErrMsg: "entry at index 0: reading expiration: unexpected EOF",
+		in:         []byte{'G', 'L', 'C', 'S', 1, 1, 1, 'k', 1, 'v'},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := cache.New(cache.Config{})
+			_, err := c.ReadFrom(bytes.NewReader(tc.in))
+			testutil.AssertErrorMsg(t, tc.wantErrMsg, err)
+		})
+	}
+}
+
+func TestCache_ReadFrom_errors(t *testing.T) {
+	t.Parallel()
+
+	c := cache.New(cache.Config{})
+	_, err := c.ReadFrom(bytes.NewReader([]byte{'G', 'L', 'C', 'S', 1, 1}))
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	_, err = c.ReadFrom(bytes.NewReader([]byte{'G', 'L', 'C', 'S', 3, 0}))
+	assert.ErrorIs(t, err, cache.ErrBadSnapshot)
+}
@@ -0,0 +1,189 @@
+package cache
+
+import "sync/atomic"
+
+// Stats - counters
+//
+// The counters are cumulative and aren't reset by Clear.
+type Stats struct {
+	// Count is the current number of elements.
+	Count int
+
+	// Size is the current total size of the elements.
+	Size int
+
+	// Hit is the number of lookups that have found an element.
+	Hit uint64
+
+	// Miss is the number of lookups that haven't found an element.
+	Miss uint64
+
+	// Overwrite is the number of sets that have replaced an element.
+	Overwrite uint64
+
+	// EvictSize is the number of elements deleted because of MaxSize.
+	EvictSize uint64
+
+	// EvictCount is the number of elements deleted because of MaxCount.
+	EvictCount uint64
+
+	// EvictExpired is the number of elements deleted because they have
+	// expired.
+	EvictExpired uint64
+
+	// RejectTooLarge is the number of sets that haven't added an element
+	// because it is larger than MaxElementSize.
+	RejectTooLarge uint64
+
+	// RejectFull is the number of sets that haven't added an element because
+	// the cache is full and its policy doesn't delete elements.
+	RejectFull uint64
+}
+
+// add adds the values of other to s.
+func (s *Stats) add(other *Stats) {
+	s.Count += other.Count
+	s.Size += other.Size
+	s.Hit += other.Hit
+	s.Miss += other.Miss
+	s.Overwrite += other.Overwrite
+	s.EvictSize += other.EvictSize
+	s.EvictCount += other.EvictCount
+	s.EvictExpired += other.EvictExpired
+	s.RejectTooLarge += other.RejectTooLarge
+	s.RejectFull += other.RejectFull
+}
+
+// EvictionReason is the reason for an automatic deletion of an element.
+type EvictionReason string
+
+// Valid eviction reasons.
+const (
+	EvictionReasonCount   EvictionReason = "count"
+	EvictionReasonExpired EvictionReason = "expired"
+	EvictionReasonSize    EvictionReason = "size"
+)
+
+// RejectionReason is the reason for a set that hasn't added an element.
+type RejectionReason string
+
+// Valid rejection reasons.
+const (
+	RejectionReasonFull     RejectionReason = "full"
+	RejectionReasonTooLarge RejectionReason = "too_large"
+)
+
+// Metrics is an interface for collection of cache statistics.  The methods are
+// called synchronously on each event, so they should be fast.  They are called
+// with the cache unlocked.  The implementations must be safe for concurrent
+// use.
+//
+// The current number and size of elements are not reported, since they can be
+// collected from [Stats] when needed.
+type Metrics interface {
+	// ObserveLookup is called on each lookup.  hit is true if the element has
+	// been found.
+	ObserveLookup(hit bool)
+
+	// ObserveSet is called on each set that has added an element.  overwrite
+	// is true if the element has replaced an existing one.
+	ObserveSet(overwrite bool)
+
+	// ObserveEviction is called each time an element is deleted
+	// automatically.
+	ObserveEviction(reason EvictionReason)
+
+	// ObserveRejection is called on each set that hasn't added an element.
+	ObserveRejection(reason RejectionReason)
+}
+
+// EmptyMetrics is an implementation of the [Metrics] interface that does
+// nothing.
+type EmptyMetrics struct{}
+
+// type check
+var _ Metrics = EmptyMetrics{}
+
+// ObserveLookup implements the [Metrics] interface for EmptyMetrics.
+func (EmptyMetrics) ObserveLookup(_ bool) {}
+
+// ObserveSet implements the [Metrics] interface for EmptyMetrics.
+func (EmptyMetrics) ObserveSet(_ bool) {}
+
+// ObserveEviction implements the [Metrics] interface for EmptyMetrics.
+func (EmptyMetrics) ObserveEviction(_ EvictionReason) {}
+
+// ObserveRejection implements the [Metrics] interface for EmptyMetrics.
+func (EmptyMetrics) ObserveRejection(_ RejectionReason) {}
+
+// counters are the cumulative counters of a cache.  They also report the
+// events to the metrics.
+type counters struct {
+	metrics Metrics
+
+	hit            atomic.Uint64
+	miss           atomic.Uint64
+	overwrite      atomic.Uint64
+	evictSize      atomic.Uint64
+	evictCount     atomic.Uint64
+	evictExpired   atomic.Uint64
+	rejectTooLarge atomic.Uint64
+	rejectFull     atomic.Uint64
+}
+
+// lookup records a lookup.
+func (c *counters) lookup(hit bool) {
+	if hit {
+		c.hit.Add(1)
+	} else {
+		c.miss.Add(1)
+	}
+
+	c.metrics.ObserveLookup(hit)
+}
+
+// set records a set that has added an element.
+func (c *counters) set(overwrite bool) {
+	if overwrite {
+		c.overwrite.Add(1)
+	}
+
+	c.metrics.ObserveSet(overwrite)
+}
+
+// evict records an eviction.
+func (c *counters) evict(reason EvictionReason) {
+	switch reason {
+	case EvictionReasonCount:
+		c.evictCount.Add(1)
+	case EvictionReasonExpired:
+		c.evictExpired.Add(1)
+	default:
+		c.evictSize.Add(1)
+	}
+
+	c.metrics.ObserveEviction(reason)
+}
+
+// reject records a rejection.
+func (c *counters) reject(reason RejectionReason) {
+	if reason == RejectionReasonFull {
+		c.rejectFull.Add(1)
+	} else {
+		c.rejectTooLarge.Add(1)
+	}
+
+	c.metrics.ObserveRejection(reason)
+}
+
+// fill sets the counter fields of s.
+func (c *counters) fill(s *Stats) {
+	s.Hit = c.hit.Load()
+	s.Miss = c.miss.Load()
+	s.Overwrite = c.overwrite.Load()
+	s.EvictSize = c.evictSize.Load()
+	s.EvictCount = c.evictCount.Load()
+	s.EvictExpired = c.evictExpired.Load()
+	s.RejectTooLarge = c.rejectTooLarge.Load()
+	s.RejectFull = c.rejectFull.Load()
+}
@@ -0,0 +1,140 @@
+package cache_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AdguardTeam/golibs/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testMetrics is a [cache.Metrics] implementation for tests that records all
+// events.
+type testMetrics struct {
+	mu         *sync.Mutex
+	lookups    []bool
+	sets       []bool
+	evictions  []cache.EvictionReason
+	rejections []cache.RejectionReason
+}
+
+// newTestMetrics returns a new properly initialized *testMetrics.
+func newTestMetrics() (m *testMetrics) {
+	return &testMetrics{
+		mu: &sync.Mutex{},
+	}
+}
+
+// type check
+var _ cache.Metrics = (*testMetrics)(nil)
+
+// ObserveLookup implements the [cache.Metrics] interface for *testMetrics.
+func (m *testMetrics) ObserveLookup(hit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lookups = append(m.lookups, hit)
+}
+
+// ObserveSet implements the [cache.Metrics] interface for *testMetrics.
+func (m *testMetrics) ObserveSet(overwrite bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sets = append(m.sets, overwrite)
+}
+
+// ObserveEviction implements the [cache.Metrics] interface for *testMetrics.
+func (m *testMetrics) ObserveEviction(reason cache.EvictionReason) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.evictions = append(m.evictions, reason)
+}
+
+// ObserveRejection implements the [cache.Metrics] interface for *testMetrics.
+func (m *testMetrics) ObserveRejection(reason cache.RejectionReason) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rejections = append(m.rejections, reason)
+}
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	m := newTestMetrics()
+	c := cache.New(cache.Config{
+		Clock:          newTestClock(&now),
+		Metrics:        m,
+		MaxSize:        8,
+		MaxElementSize: 6,
+		MaxCount:       3,
+		EnableLRU:      true,
+	})
+
+	require.False(t, c.Set([]byte("k1"), []byte("v1")))
+	require.True(t, c.Set([]byte("k1"), []byte("v1")))
+	require.False(t, c.Set([]byte("k2"), []byte("1234")))
+	require.False(t, c.Set([]byte("k3"), []byte("12345678")))
+
+	require.Nil(t, c.Get([]byte("k1")))
+	require.NotNil(t, c.Get([]byte("k2")))
+
+	require.False(t, c.SetWithTTL([]byte("a"), []byte("1"), testTTL))
+	require.False(t, c.SetWithTTL([]byte("b"), []byte("2"), testTTL))
+
+	now = now.Add(testTTL)
+
+	require.Equal(t, 2, c.DeleteExpired())
+
+	require.False(t, c.Set([]byte("c"), []byte("3")))
+	require.False(t, c.Set([]byte("d"), []byte("4")))
+	require.False(t, c.Set([]byte("e"), []byte("5")))
+	require.False(t, c.Set([]byte("f"), []byte("6")))
+
+	c.Clear()
+
+	assert.Equal(t, cache.Stats{
+		Count:          0,
+		Size:           0,
+		Hit:            1,
+		Miss:           1,
+		Overwrite:      1,
+		EvictSize:      2,
+		EvictCount:     1,
+		EvictExpired:   2,
+		RejectTooLarge: 1,
+		RejectFull:     0,
+	}, c.Stats())
+
+	assert.Equal(t, []bool{false, true}, m.lookups)
+	assert.Equal(t, []bool{false, true, false, false, false, false, false, false, false}, m.sets)
+	assert.Equal(t, []cache.EvictionReason{
+		cache.EvictionReasonSize,
+		cache.EvictionReasonSize,
+		cache.EvictionReasonExpired,
+		cache.EvictionReasonExpired,
+		cache.EvictionReasonCount,
+	}, m.evictions)
+	assert.Equal(t, []cache.RejectionReason{cache.RejectionReasonTooLarge}, m.rejections)
+}
+
+func TestStats_rejectFull(t *testing.T) {
+	t.Parallel()
+
+	m := newTestMetrics()
+	c := cache.NewTyped(cache.TypedConfig[int, int]{
+		Metrics:  m,
+		MaxCount: 1,
+	})
+
+	require.False(t, c.Set(1, 1))
+	require.False(t, c.Set(2, 2))
+
+	assert.Equal(t, uint64(1), c.Stats().RejectFull)
+	assert.Equal(t, []cache.RejectionReason{cache.RejectionReasonFull}, m.rejections)
+}
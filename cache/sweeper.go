@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/AdguardTeam/golibs/service"
+	"github.com/AdguardTeam/golibs/timeutil"
+)
+
+// SweeperConfig is the configuration for a sweeper created by [NewSweeper].
+type SweeperConfig struct {
+	// Clock is used to schedule the sweeps.  If it is nil,
+	// [timeutil.SystemClock] is used.
+	Clock timeutil.ClockAfter
+
+	// Cache is the cache to delete the expired elements from.  It must not be
+	// nil.
+	Cache Expirer
+
+	// Interval is the interval between two sweeps.  It must be positive.
+	Interval time.Duration
+}
+
+// NewSweeper returns a service that periodically deletes expired elements from
+// the cache.  The service must be started with Start and stopped with
+// Shutdown, for example using [service.SignalHandler].  c must not be nil.
+func NewSweeper(c *SweeperConfig) (svc service.Interface) {
+	return service.NewRefreshWorker(&service.RefreshWorkerConfig{
+		Clock:     c.Clock,
+		Refresher: &sweeper{cache: c.Cache},
+		Schedule:  timeutil.NewConstSchedule(c.Interval),
+	})
+}
+
+// sweeper is a [service.Refresher] that deletes expired elements.
+type sweeper struct {
+	cache Expirer
+}
+
+// type check
+var _ service.Refresher = (*sweeper)(nil)
+
+// Refresh implements the [service.Refresher] interface for *sweeper.  err is
+// always nil.
+func (s *sweeper) Refresh(_ context.Context) (err error) {
+	_ = s.cache.DeleteExpired()
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AdguardTeam/golibs/cache"
+	"github.com/AdguardTeam/golibs/testutil"
+	"github.com/AdguardTeam/golibs/testutil/servicetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signalExpirer is a [cache.Expirer] that sends the results of the wrapped
+// expirer to a channel, if it is not full.
+type signalExpirer struct {
+	exp  cache.Expirer
+	resp chan int
+}
+
+// type check
+var _ cache.Expirer = (*signalExpirer)(nil)
+
+// DeleteExpired implements the [cache.Expirer] interface for *signalExpirer.
+func (e *signalExpirer) DeleteExpired() (n int) {
+	n = e.exp.DeleteExpired()
+	select {
+	case e.resp <- n:
+	default:
+	}
+
+	return n
+}
+
+func TestNewSweeper(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	c := cache.New(cache.Config{
+		Clock: newTestClock(&now),
+	})
+
+	require.False(t, c.SetWithTTL([]byte("k1"), []byte("v1"), testTTL))
+	require.False(t, c.Set([]byte("k2"), []byte("v2")))
+
+	now = now.Add(testTTL)
+
+	exp := &signalExpirer{
+		exp:  c,
+		resp: make(chan int, 1),
+	}
+
+	svc := cache.NewSweeper(&cache.SweeperConfig{
+		Cache:    exp,
+		Interval: 1 * time.Millisecond,
+	})
+	servicetest.RequireRun(t, svc, testTimeout)
+
+	n, _ := testutil.RequireReceive(t, exp.resp, testTimeout)
+	assert.Equal(t, 1, n)
+
+	assert.Nil(t, c.Get([]byte("k1")))
+	assert.Equal(t, []byte("v2"), c.Get([]byte("k2")))
+}
@@ -0,0 +1,210 @@
+package cache
+
+import (
+	"io"
+	"time"
+
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/AdguardTeam/golibs/validate"
+)
+
+// TwoTierConfig is the configuration for a two-tier [Cache].
+type TwoTierConfig struct {
+	// Local is the first tier, usually a small in-memory cache created with
+	// [New].  It must not be nil.
+	Local Cache
+
+	// Remote is the second tier, usually a shared cache created with
+	// [NewRedis].  It must not be nil.
+	Remote Cache
+
+	// LocalTTL is the maximum TTL of the elements in Local.  Since Local isn't
+	// updated when Remote is changed by other instances, it limits the time
+	// during which the elements in Local may be stale.  It must be positive.
+	LocalTTL time.Duration
+}
+
+// ttlCache is the interface for the remote caches that report the TTLs of
+// their elements, so that the local tier doesn't keep the elements longer than
+// the remote one.
+type ttlCache interface {
+	Cache
+
+	// defaultTTL returns the TTL of the elements added with Set.  ttl is zero
+	// if those elements never expire.
+	defaultTTL() (ttl time.Duration)
+
+	// getWithTTL is like Get but also returns the remaining TTL of the
+	// element.  ttl is zero if the element never expires.
+	getWithTTL(key []byte) (val []byte, ttl time.Duration)
+}
+
+// twoTierCache is a [Cache] that puts a local cache in front of a remote one.
+// The elements are written through to the remote cache.
+type twoTierCache struct {
+	local  Cache
+	remote Cache
+
+	// remoteTTL is remote if it implements [ttlCache], and nil otherwise.
+	remoteTTL ttlCache
+
+	localTTL time.Duration
+}
+
+// type check
+var _ Cache = (*twoTierCache)(nil)
+
+// NewTwoTier returns a new [Cache] that looks up elements in c.Local first and
+// then in c.Remote, adding the elements found in c.Remote to c.Local.  The
+// elements are written to both tiers.  c must not be nil and must be valid.
+//
+// The TTL of the elements in c.Local is c.LocalTTL or their TTL in c.Remote,
+// whichever is less.  For caches created with [NewRedis], that is the
+// remaining TTL of the elements found in c.Remote and the configured TTL of the
+// elements added with Set.  For other remote caches, the elements found in
+// c.Remote and the ones added with Set have c.LocalTTL.
+//
+// Range, DeleteFunc, WriteTo, and ReadFrom only use c.Remote, which is
+// considered to contain all elements, except that DeleteFunc also deletes the
+// elements from c.Local.  Stats returns the statistics of c.Local.
+func NewTwoTier(c *TwoTierConfig) (tt Cache, err error) {
+	err = validate.NotNil("c", c)
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return nil, err
+	}
+
+	err = errors.Join(
+		validate.NotNilInterface("c.Local", c.Local),
+		validate.NotNilInterface("c.Remote", c.Remote),
+		validate.Positive("c.LocalTTL", c.LocalTTL),
+	)
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return nil, err
+	}
+
+	remoteTTL, _ := c.Remote.(ttlCache)
+
+	return &twoTierCache{
+		local:     c.Local,
+		remote:    c.Remote,
+		remoteTTL: remoteTTL,
+		localTTL:  c.LocalTTL,
+	}, nil
+}
+
+// localTTLFor returns the TTL for the local tier for an element with ttl in
+// the remote tier.
+func (c *twoTierCache) localTTLFor(ttl time.Duration) (localTTL time.Duration) {
+	if ttl > 0 && ttl < c.localTTL {
+		return ttl
+	}
+
+	return c.localTTL
+}
+
+// Set implements the [Cache] interface for *twoTierCache.  exists is reported
+// by the remote tier.
+func (c *twoTierCache) Set(key, val []byte) (exists bool) {
+	exists = c.remote.Set(key, val)
+
+	localTTL := c.localTTL
+	if c.remoteTTL != nil {
+		localTTL = c.localTTLFor(c.remoteTTL.defaultTTL())
+	}
+
+	_ = c.local.SetWithTTL(key, val, localTTL)
+
+	return exists
+}
+
+// SetWithTTL implements the [Cache] interface for *twoTierCache.  exists is
+// reported by the remote tier.
+func (c *twoTierCache) SetWithTTL(key, val []byte, ttl time.Duration) (exists bool) {
+	exists = c.remote.SetWithTTL(key, val, ttl)
+	_ = c.local.SetWithTTL(key, val, c.localTTLFor(ttl))
+
+	return exists
+}
+
+// Get implements the [Cache] interface for *twoTierCache.
+func (c *twoTierCache) Get(key []byte) (val []byte) {
+	val = c.local.Get(key)
+	if val != nil {
+		return val
+	}
+
+	localTTL := c.localTTL
+	if c.remoteTTL != nil {
+		var ttl time.Duration
+		val, ttl = c.remoteTTL.getWithTTL(key)
+		localTTL = c.localTTLFor(ttl)
+	} else {
+		val = c.remote.Get(key)
+	}
+
+	if val != nil {
+		_ = c.local.SetWithTTL(key, val, localTTL)
+	}
+
+	return val
+}
+
+// Del implements the [Cache] interface for *twoTierCache.
+func (c *twoTierCache) Del(key []byte) {
+	c.remote.Del(key)
+	c.local.Del(key)
+}
+
+// Peek implements the [Cache] interface for *twoTierCache.  It doesn't add the
+// element found in the remote tier to the local one.
+func (c *twoTierCache) Peek(key []byte) (val []byte) {
+	val = c.local.Peek(key)
+	if val != nil {
+		return val
+	}
+
+	return c.remote.Peek(key)
+}
+
+// Range implements the [Cache] interface for *twoTierCache.
+func (c *twoTierCache) Range(f func(key, val []byte) (cont bool)) {
+	c.remote.Range(f)
+}
+
+// DeleteFunc implements the [Cache] interface for *twoTierCache.  n is the
+// number of elements deleted from the remote tier.
+func (c *twoTierCache) DeleteFunc(del func(key, val []byte) (ok bool)) (n int) {
+	_ = c.local.DeleteFunc(del)
+
+	return c.remote.DeleteFunc(del)
+}
+
+// DeleteExpired implements the [Expirer] interface for *twoTierCache.  n is
+// the total number of elements deleted from both tiers.
+func (c *twoTierCache) DeleteExpired() (n int) {
+	return c.local.DeleteExpired() + c.remote.DeleteExpired()
+}
+
+// Clear implements the [Cache] interface for *twoTierCache.
+func (c *twoTierCache) Clear() {
+	c.remote.Clear()
+	c.local.Clear()
+}
+
+// Stats implements the [Cache] interface for *twoTierCache.
+func (c *twoTierCache) Stats() (s Stats) {
+	return c.local.Stats()
+}
+
+// WriteTo implements the [io.WriterTo] interface for *twoTierCache.
+func (c *twoTierCache) WriteTo(w io.Writer) (n int64, err error) {
+	return c.remote.WriteTo(w)
+}
+
+// ReadFrom implements the [io.ReaderFrom] interface for *twoTierCache.  The
+// elements are only added to the remote tier.
+func (c *twoTierCache) ReadFrom(r io.Reader) (n int64, err error) {
+	return c.remote.ReadFrom(r)
+}
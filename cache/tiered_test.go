@@ -0,0 +1,119 @@
+package cache_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/AdguardTeam/golibs/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTwoTier(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	r, p := newFakeRedis(&now)
+	remote := newTestRedis(t, &now, p)
+	local := cache.New(cache.Config{
+		Clock:     newTestClock(&now),
+		MaxCount:  10,
+		EnableLRU: true,
+	})
+
+	const localTTL = testTTL / 2
+
+	c, err := cache.NewTwoTier(&cache.TwoTierConfig{
+		Local:    local,
+		Remote:   remote,
+		LocalTTL: localTTL,
+	})
+	require.NoError(t, err)
+
+	// Write-through.
+	require.False(t, c.Set([]byte("k1"), []byte("v1")))
+	assert.Equal(t, []byte("v1"), local.Peek([]byte("k1")))
+	assert.Equal(t, []byte("v1"), remote.Peek([]byte("k1")))
+
+	// The local tier is used first.
+	r.data[testKeyPrefix+"k1"] = fakeRedisEntry{val: []byte("new")}
+	assert.Equal(t, []byte("v1"), c.Get([]byte("k1")))
+
+	// The local elements expire after the local TTL.
+	now = now.Add(localTTL)
+	assert.Equal(t, []byte("new"), c.Get([]byte("k1")))
+	assert.Equal(t, []byte("new"), local.Peek([]byte("k1")))
+
+	// The elements found in the remote tier are added to the local one.
+	r.data[testKeyPrefix+"k2"] = fakeRedisEntry{val: []byte("v2")}
+	assert.Nil(t, local.Peek([]byte("k2")))
+	assert.Equal(t, []byte("v2"), c.Peek([]byte("k2")))
+	assert.Nil(t, local.Peek([]byte("k2")))
+	assert.Equal(t, []byte("v2"), c.Get([]byte("k2")))
+	assert.Equal(t, []byte("v2"), local.Peek([]byte("k2")))
+
+	c.Del([]byte("k2"))
+	assert.Nil(t, local.Peek([]byte("k2")))
+	assert.Nil(t, remote.Peek([]byte("k2")))
+
+	buf := &bytes.Buffer{}
+	_, err = c.WriteTo(buf)
+	require.NoError(t, err)
+
+	c.Clear()
+	assert.Nil(t, c.Get([]byte("k1")))
+
+	_, err = c.ReadFrom(buf)
+	require.NoError(t, err)
+
+	assert.Nil(t, local.Peek([]byte("k1")))
+	assert.Equal(t, []byte("new"), c.Get([]byte("k1")))
+}
+
+func TestNewTwoTier_remoteTTL(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	r, p := newFakeRedis(&now)
+	local := cache.New(cache.Config{
+		Clock:     newTestClock(&now),
+		MaxCount:  10,
+		EnableLRU: true,
+	})
+
+	c, err := cache.NewTwoTier(&cache.TwoTierConfig{
+		Local:    local,
+		Remote:   newTestRedis(t, &now, p),
+		LocalTTL: 2 * testTTL,
+	})
+	require.NoError(t, err)
+
+	// The elements added with Set have the configured TTL of the remote tier.
+	require.False(t, c.Set([]byte("k1"), []byte("v1")))
+
+	// The elements found in the remote tier have their remaining TTL.
+	r.data[testKeyPrefix+"k2"] = fakeRedisEntry{
+		val:    []byte("v2"),
+		expire: now.Add(testTTL / 2),
+	}
+	assert.Equal(t, []byte("v2"), c.Get([]byte("k2")))
+
+	now = now.Add(testTTL / 2)
+	assert.Equal(t, []byte("v1"), local.Peek([]byte("k1")))
+	assert.Nil(t, local.Peek([]byte("k2")))
+
+	now = now.Add(testTTL / 2)
+	assert.Nil(t, local.Peek([]byte("k1")))
+}
+
+func TestNewTwoTier_error(t *testing.T) {
+	t.Parallel()
+
+	_, err := cache.NewTwoTier(&cache.TwoTierConfig{})
+	require.Error(t, err)
+
+	assert.ErrorContains(t, err, "c.Local")
+	assert.ErrorContains(t, err, "c.Remote")
+	assert.ErrorContains(t, err, "c.LocalTTL")
+}
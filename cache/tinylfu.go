@@ -0,0 +1,219 @@
+package cache
+
+import "github.com/AdguardTeam/golibs/container"
+
+// segment is a segment of the W-TinyLFU policy that contains an item.
+type segment uint8
+
+// Valid segments.
+const (
+	segmentWindow segment = iota
+	segmentProbation
+	segmentProtected
+)
+
+// lruSegment is an LRU list of items with the total number and size of items.
+type lruSegment[K comparable, V any] struct {
+	// list is the list of items, from the least recently used to the most
+	// recently used one.
+	list container.LinkedList[*item[K, V]]
+
+	count uint
+	size  uint
+}
+
+// init initializes or clears s.
+func (s *lruSegment[K, V]) init() {
+	s.list.Clear()
+	s.count = 0
+	s.size = 0
+}
+
+// isEmpty returns true if s contains no items.
+func (s *lruSegment[K, V]) isEmpty() (ok bool) {
+	return s.list.Len() == 0
+}
+
+// isOver returns true if s contains more items than allowed by the limits.
+func (s *lruSegment[K, V]) isOver(maxSize, maxCount uint) (ok bool) {
+	return s.size > maxSize || s.count > maxCount
+}
+
+// wTinyLFUPolicy is the W-TinyLFU policy.  See [PolicyWTinyLFU].
+type wTinyLFUPolicy[K comparable, V any] struct {
+	sketch *frequencySketch[K]
+
+	window    lruSegment[K, V]
+	probation lruSegment[K, V]
+	protected lruSegment[K, V]
+
+	windowMaxSize     uint
+	windowMaxCount    uint
+	protectedMaxSize  uint
+	protectedMaxCount uint
+}
+
+// W-TinyLFU segment shares, in percents.
+const (
+	// windowPercent is the share of the window in the whole cache.
+	windowPercent = 1
+
+	// protectedPercent is the share of the protected segment in the main part
+	// of the cache.
+	protectedPercent = 80
+)
+
+// newWTinyLFUPolicy returns a new properly initialized *wTinyLFUPolicy for a
+// cache with the given limits.
+func newWTinyLFUPolicy[K comparable, V any](maxSize, maxCount uint) (p *wTinyLFUPolicy[K, V]) {
+	p = &wTinyLFUPolicy[K, V]{
+		sketch:         newFrequencySketch[K](maxCount),
+		windowMaxSize:  max(maxSize/100*windowPercent, 1),
+		windowMaxCount: max(maxCount/100*windowPercent, 1),
+	}
+
+	p.protectedMaxSize = (maxSize - p.windowMaxSize) / 100 * protectedPercent
+	p.protectedMaxCount = (maxCount - p.windowMaxCount) / 100 * protectedPercent
+
+	p.reset()
+
+	return p
+}
+
+// type check
+var _ evictionPolicy[string, []byte] = (*wTinyLFUPolicy[string, []byte])(nil)
+
+// add implements the [evictionPolicy] interface for *wTinyLFUPolicy.
+func (p *wTinyLFUPolicy[K, V]) add(it *item[K, V]) {
+	p.sketch.increment(it.key)
+	p.push(&p.window, it, segmentWindow)
+
+	// The cache has already freed space for the new item, so moving the items
+	// from the window into the main part doesn't require any deletions.
+	for p.window.count > 1 && p.window.isOver(p.windowMaxSize, p.windowMaxCount) {
+		p.move(p.first(&p.window), &p.probation, segmentProbation)
+	}
+}
+
+// access implements the [evictionPolicy] interface for *wTinyLFUPolicy.
+func (p *wTinyLFUPolicy[K, V]) access(it *item[K, V]) {
+	p.sketch.increment(it.key)
+
+	switch it.seg {
+	case segmentWindow:
+		p.move(it, &p.window, segmentWindow)
+	case segmentProbation:
+		p.move(it, &p.protected, segmentProtected)
+		for p.protected.count > 1 &&
+			p.protected.isOver(p.protectedMaxSize, p.protectedMaxCount) {
+			p.move(p.first(&p.protected), &p.probation, segmentProbation)
+		}
+	default:
+		p.move(it, &p.protected, segmentProtected)
+	}
+}
+
+// miss implements the [evictionPolicy] interface for *wTinyLFUPolicy.
+func (p *wTinyLFUPolicy[K, V]) miss(key K) {
+	p.sketch.increment(key)
+}
+
+// remove implements the [evictionPolicy] interface for *wTinyLFUPolicy.
+func (p *wTinyLFUPolicy[K, V]) remove(it *item[K, V]) {
+	s := p.segment(it.seg)
+	s.list.Remove(&it.used)
+	s.count--
+	s.size -= it.size
+}
+
+// victim implements the [evictionPolicy] interface for *wTinyLFUPolicy.  If
+// the window is full, the least recently used item of the window is the
+// candidate for admission into the main part of the cache.  The candidate is
+// admitted only if it is used more frequently than the victim from the main
+// part; otherwise, the candidate itself is deleted.
+func (p *wTinyLFUPolicy[K, V]) victim() (it *item[K, V]) {
+	mainVictim := p.mainVictim()
+	if p.window.isEmpty() {
+		return mainVictim
+	}
+
+	cand := p.first(&p.window)
+	if mainVictim == nil {
+		return cand
+	}
+
+	windowFull := p.window.size >= p.windowMaxSize || p.window.count >= p.windowMaxCount
+	if !windowFull {
+		return mainVictim
+	}
+
+	if p.sketch.estimate(cand.key) > p.sketch.estimate(mainVictim.key) {
+		p.move(cand, &p.probation, segmentProbation)
+
+		return mainVictim
+	}
+
+	return cand
+}
+
+// mainVictim returns the least recently used item of the main part of the
+// cache or nil if the main part is empty.
+func (p *wTinyLFUPolicy[K, V]) mainVictim() (it *item[K, V]) {
+	if !p.probation.isEmpty() {
+		return p.first(&p.probation)
+	} else if !p.protected.isEmpty() {
+		return p.first(&p.protected)
+	}
+
+	return nil
+}
+
+// reset implements the [evictionPolicy] interface for *wTinyLFUPolicy.
+func (p *wTinyLFUPolicy[K, V]) reset() {
+	p.sketch.reset()
+	p.window.init()
+	p.probation.init()
+	p.protected.init()
+}
+
+// appendItems implements the [evictionPolicy] interface for *wTinyLFUPolicy.
+// The items of the main part are appended before the ones of the window.
+func (p *wTinyLFUPolicy[K, V]) appendItems(items []*item[K, V]) (res []*item[K, V]) {
+	res = append(items, p.probation.list.Values()...)
+	res = append(res, p.protected.list.Values()...)
+
+	return append(res, p.window.list.Values()...)
+}
+
+// segment returns the segment for seg.
+func (p *wTinyLFUPolicy[K, V]) segment(seg segment) (s *lruSegment[K, V]) {
+	switch seg {
+	case segmentWindow:
+		return &p.window
+	case segmentProbation:
+		return &p.probation
+	default:
+		return &p.protected
+	}
+}
+
+// first returns the least recently used item of s.  s must not be empty.
+func (p *wTinyLFUPolicy[K, V]) first(s *lruSegment[K, V]) (it *item[K, V]) {
+	return s.list.Front().Value
+}
+
+// push adds it as the most recently used item of s.
+func (p *wTinyLFUPolicy[K, V]) push(s *lruSegment[K, V], it *item[K, V], seg segment) {
+	it.used.Value = it
+	s.list.PushBackElement(&it.used)
+	s.count++
+	s.size += it.size
+	it.seg = seg
+}
+
+// move moves it from its current segment to the most recently used position
+// of s.
+func (p *wTinyLFUPolicy[K, V]) move(it *item[K, V], s *lruSegment[K, V], seg segment) {
+	p.remove(it)
+	p.push(s, it, seg)
+}
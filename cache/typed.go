@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/AdguardTeam/golibs/timeutil"
+)
+
+// TypedConfig is the configuration for a [Typed] cache.  It has the same
+// semantics as [Config], except that the size of an element is calculated by
+// Size instead of the lengths of the key and the value.
+type TypedConfig[K comparable, V any] struct {
+	// OnDelete is called after an element has been deleted automatically,
+	// including when it has expired.  If it is nil, it is not called.
+	OnDelete func(key K, val V)
+
+	// Size returns the size of an element, which is used for MaxSize and
+	// MaxElementSize limits as well as [Stats.Size].  It must be a pure
+	// function.  If it is nil, the size of every element is zero, so that only
+	// MaxCount limits the cache.
+	Size func(key K, val V) (n uint)
+
+	// Clock is used to calculate the expiration of elements.  If it is nil,
+	// [timeutil.SystemClock] is used.
+	Clock timeutil.Clock
+
+	// Metrics is used for the collection of the cache statistics.  It is
+	// shared between the shards.  If it is nil, [EmptyMetrics] is used.
+	Metrics Metrics
+
+	// MaxSize is the maximum total size of all elements, as reported by Size.
+	// If it is zero, the size is unlimited.
+	MaxSize uint
+
+	// MaxElementSize is the maximum size of a single element, as reported by
+	// Size.  If it is zero or greater than MaxSize, MaxSize is used.
+	MaxElementSize uint
+
+	// MaxCount is the maximum number of elements.  If it is zero, the number
+	// is unlimited.
+	MaxCount uint
+
+	// ShardCount is the number of shards.  If it is greater than one, the
+	// elements are split between the shards by the hash of the key and each
+	// shard has its own lock, which reduces lock contention.  MaxSize and
+	// MaxCount are split evenly between the shards, so an element may be
+	// deleted before the whole cache is full.
+	ShardCount uint
+
+	// EnableLRU, if true, makes the cache delete the least recently used
+	// element automatically when it is full.  Otherwise, Set refuses to add
+	// new elements to a full cache.
+	EnableLRU bool
+
+	// Policy is the eviction policy.  If it is not [PolicyDefault], EnableLRU
+	// is ignored.
+	Policy Policy
+}
+
+// Typed is a cache that stores keys and values of arbitrary types without
+// serializing them.
+type Typed[K comparable, V any] interface {
+	// Set sets the value for key.  replaced is true if a previous value has
+	// been replaced.
+	Set(key K, val V) (replaced bool)
+
+	// SetWithTTL is like Set but the element expires after ttl.  If ttl is not
+	// positive, the element never expires.  Expired elements are deleted
+	// lazily on Get or by DeleteExpired, so they are counted in Stats until
+	// then.
+	SetWithTTL(key K, val V, ttl time.Duration) (replaced bool)
+
+	// Get returns the value for key.  ok is false if there is no such
+	// element.
+	Get(key K) (val V, ok bool)
+
+	// Del deletes the element for key, if any.
+	Del(key K)
+
+	// Peek is like Get but it doesn't make the element recently used for the
+	// eviction policy and doesn't change the statistics.  It also doesn't
+	// delete the element if it has expired.
+	Peek(key K) (val V, ok bool)
+
+	// Range calls f for each element that hasn't expired, from the one that
+	// would be deleted first to the one that would be deleted last, until f
+	// returns false.  With several shards, the order is only kept within each
+	// shard.  The elements are collected before calling f, so f may call the
+	// methods of the cache.
+	Range(f func(key K, val V) (cont bool))
+
+	// DeleteFunc deletes all elements for which del returns true and returns
+	// the number of deleted elements.  OnDelete is not called for them.  del
+	// is called with the cache locked, so it must not call the methods of the
+	// cache.
+	DeleteFunc(del func(key K, val V) (ok bool)) (n int)
+
+	Expirer
+
+	// Clear deletes all elements.  The counters in Stats are not reset.
+	Clear()
+
+	// Stats returns the statistics of the cache.
+	Stats() (s Stats)
+}
+
+// NewTyped returns a new typed cache with the given configuration.
+func NewTyped[K comparable, V any](conf TypedConfig[K, V]) (c Typed[K, V]) {
+	return newTyped(conf)
+}
+
+// internalTyped is the interface for the implementations of [Typed] in this
+// package.
+type internalTyped[K comparable, V any] interface {
+	Typed[K, V]
+
+	// snapshot returns the items that haven't expired in the order in which
+	// they should be added to another cache to get the same state.
+	snapshot() (items []*item[K, V])
+}
+
+// newTyped returns a new typed cache with the given configuration.
+func newTyped[K comparable, V any](conf TypedConfig[K, V]) (c internalTyped[K, V]) {
+	if conf.ShardCount > 1 {
+		return newShardedCache(conf, conf.ShardCount)
+	}
+
+	return newTypedCache(conf)
+}
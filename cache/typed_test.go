@@ -0,0 +1,178 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AdguardTeam/golibs/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testValue is a value type for tests.
+type testValue struct {
+	name string
+	num  int
+}
+
+// testSize is a size function for tests.
+func testSize(_ int, v testValue) (n uint) {
+	return uint(len(v.name))
+}
+
+func TestTyped(t *testing.T) {
+	t.Parallel()
+
+	var deletedKeys []int
+	c := cache.NewTyped(cache.TypedConfig[int, testValue]{
+		OnDelete: func(k int, _ testValue) {
+			deletedKeys = append(deletedKeys, k)
+		},
+		Size:      testSize,
+		MaxSize:   8,
+		MaxCount:  3,
+		EnableLRU: true,
+	})
+
+	v, ok := c.Get(1)
+	require.False(t, ok)
+	assert.Zero(t, v)
+
+	require.False(t, c.Set(1, testValue{name: "a", num: 1}))
+	require.False(t, c.Set(2, testValue{name: "bb", num: 2}))
+	require.True(t, c.Set(1, testValue{name: "aa", num: 10}))
+
+	v, ok = c.Get(1)
+	require.True(t, ok)
+	assert.Equal(t, testValue{name: "aa", num: 10}, v)
+
+	assert.Equal(t, cache.Stats{Count: 2, Size: 4, Hit: 1, Miss: 1, Overwrite: 1}, c.Stats())
+
+	// Promote key 2, so that key 1 is the least recently used one.
+	_, ok = c.Get(2)
+	require.True(t, ok)
+
+	require.False(t, c.Set(3, testValue{name: "ccc", num: 3}))
+	require.False(t, c.Set(4, testValue{name: "d", num: 4}))
+	assert.Equal(t, []int{1}, deletedKeys)
+
+	_, ok = c.Get(1)
+	assert.False(t, ok)
+
+	c.Del(2)
+	_, ok = c.Get(2)
+	assert.False(t, ok)
+
+	c.Clear()
+	assert.Equal(t, cache.Stats{
+		Count:      0,
+		Size:       0,
+		Hit:        2,
+		Miss:       3,
+		Overwrite:  1,
+		EvictCount: 1,
+	}, c.Stats())
+}
+
+func TestTyped_noLRU(t *testing.T) {
+	t.Parallel()
+
+	c := cache.NewTyped(cache.TypedConfig[int, testValue]{
+		MaxCount: 1,
+	})
+
+	require.False(t, c.Set(1, testValue{name: "a"}))
+	require.False(t, c.Set(2, testValue{name: "b"}))
+
+	_, ok := c.Get(2)
+	assert.False(t, ok)
+
+	assert.Equal(t, cache.Stats{Count: 1, Size: 0, Hit: 0, Miss: 1, RejectFull: 1}, c.Stats())
+}
+
+func TestTyped_maxElementSize(t *testing.T) {
+	t.Parallel()
+
+	c := cache.NewTyped(cache.TypedConfig[int, testValue]{
+		Size:           testSize,
+		MaxElementSize: 2,
+	})
+
+	require.False(t, c.Set(1, testValue{name: "abc"}))
+
+	_, ok := c.Get(1)
+	assert.False(t, ok)
+}
+
+func TestTyped_SetWithTTL(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	var deletedKeys []int
+	c := cache.NewTyped(cache.TypedConfig[int, testValue]{
+		OnDelete: func(k int, _ testValue) {
+			deletedKeys = append(deletedKeys, k)
+		},
+		Size:  testSize,
+		Clock: newTestClock(&now),
+	})
+
+	require.False(t, c.SetWithTTL(1, testValue{name: "a"}, testTTL))
+	require.False(t, c.SetWithTTL(2, testValue{name: "bb"}, 2*testTTL))
+	require.False(t, c.Set(3, testValue{name: "ccc"}))
+
+	_, ok := c.Get(1)
+	require.True(t, ok)
+
+	now = now.Add(testTTL)
+
+	_, ok = c.Get(1)
+	assert.False(t, ok)
+	assert.Equal(t, []int{1}, deletedKeys)
+	assert.Equal(t, cache.Stats{Count: 2, Size: 5, Hit: 1, Miss: 1, EvictExpired: 1}, c.Stats())
+
+	require.False(t, c.SetWithTTL(1, testValue{name: "a"}, testTTL))
+
+	now = now.Add(testTTL)
+
+	assert.Equal(t, 2, c.DeleteExpired())
+	assert.ElementsMatch(t, []int{1, 1, 2}, deletedKeys)
+	assert.Equal(t, cache.Stats{Count: 1, Size: 3, Hit: 1, Miss: 1, EvictExpired: 3}, c.Stats())
+
+	require.False(t, c.SetWithTTL(2, testValue{name: "bb"}, testTTL))
+
+	now = now.Add(testTTL)
+
+	// An expired value is not considered replaced.
+	assert.False(t, c.Set(2, testValue{name: "bb"}))
+	assert.Equal(t, uint64(4), c.Stats().EvictExpired)
+}
+
+func TestTyped_SetWithTTL_noLRU(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	c := cache.NewTyped(cache.TypedConfig[int, testValue]{
+		Clock: newTestClock(&now),
+	})
+
+	require.False(t, c.SetWithTTL(1, testValue{name: "a"}, testTTL))
+	require.True(t, c.SetWithTTL(1, testValue{name: "b"}, testTTL))
+
+	now = now.Add(testTTL)
+
+	_, ok := c.Get(1)
+	assert.False(t, ok)
+
+	require.False(t, c.Set(2, testValue{name: "c"}))
+	c.Del(2)
+
+	assert.Equal(t, cache.Stats{
+		Count:        0,
+		Size:         0,
+		Hit:          0,
+		Miss:         1,
+		Overwrite:    1,
+		EvictExpired: 1,
+	}, c.Stats())
+}
@@ -0,0 +1,206 @@
+package container
+
+// BiMultiMap is a many-to-many map between keys and values that can be queried
+// from either side.  Both the values of each key and the keys of each value
+// are kept in the order in which the pairs have been added.  It must be
+// initialized with [NewBiMultiMap].  It is not safe for concurrent use.
+type BiMultiMap[K, V comparable] struct {
+	// values maps each key to its values.
+	values map[K]*OrderedMap[V, unit]
+
+	// keys maps each value to its keys.
+	keys map[V]*OrderedMap[K, unit]
+
+	// len is the number of pairs.
+	len int
+}
+
+// NewBiMultiMap returns a new empty *BiMultiMap.
+func NewBiMultiMap[K, V comparable]() (m *BiMultiMap[K, V]) {
+	return &BiMultiMap[K, V]{
+		values: map[K]*OrderedMap[V, unit]{},
+		keys:   map[V]*OrderedMap[K, unit]{},
+	}
+}
+
+// Add adds the pair of key and v to m.  added is false if the pair is already
+// in m, in which case its position doesn't change.
+func (m *BiMultiMap[K, V]) Add(key K, v V) (added bool) {
+	values := m.values[key]
+	if values.Has(v) {
+		return false
+	}
+
+	if values == nil {
+		values = NewOrderedMap[V, unit]()
+		m.values[key] = values
+	}
+
+	keys := m.keys[v]
+	if keys == nil {
+		keys = NewOrderedMap[K, unit]()
+		m.keys[v] = keys
+	}
+
+	values.Set(v, unit{})
+	keys.Set(key, unit{})
+	m.len++
+
+	return true
+}
+
+// Delete deletes the pair of key and v from m and returns true if it was
+// there.  Calling Delete on a nil map has no effect.
+func (m *BiMultiMap[K, V]) Delete(key K, v V) (ok bool) {
+	if m == nil || !m.values[key].Delete(v) {
+		return false
+	}
+
+	if m.values[key].Len() == 0 {
+		delete(m.values, key)
+	}
+
+	keys := m.keys[v]
+	keys.Delete(key)
+	if keys.Len() == 0 {
+		delete(m.keys, v)
+	}
+
+	m.len--
+
+	return true
+}
+
+// DeleteKey deletes all pairs with key from m and returns the number of the
+// deleted pairs.  Calling DeleteKey on a nil map has no effect.
+func (m *BiMultiMap[K, V]) DeleteKey(key K) (n int) {
+	for _, v := range m.Values(key) {
+		_ = m.Delete(key, v)
+		n++
+	}
+
+	return n
+}
+
+// DeleteValue deletes all pairs with v from m and returns the number of the
+// deleted pairs.  Calling DeleteValue on a nil map has no effect.
+func (m *BiMultiMap[K, V]) DeleteValue(v V) (n int) {
+	for _, key := range m.Keys(v) {
+		_ = m.Delete(key, v)
+		n++
+	}
+
+	return n
+}
+
+// Has returns true if the pair of key and v is in m.  Calling Has on a nil map
+// returns false.
+func (m *BiMultiMap[K, V]) Has(key K, v V) (ok bool) {
+	return m != nil && m.values[key].Has(v)
+}
+
+// HasKey returns true if m contains any pairs with key.  Calling HasKey on a
+// nil map returns false.
+func (m *BiMultiMap[K, V]) HasKey(key K) (ok bool) {
+	if m != nil {
+		_, ok = m.values[key]
+	}
+
+	return ok
+}
+
+// HasValue returns true if m contains any pairs with v.  Calling HasValue on a
+// nil map returns false.
+func (m *BiMultiMap[K, V]) HasValue(v V) (ok bool) {
+	if m != nil {
+		_, ok = m.keys[v]
+	}
+
+	return ok
+}
+
+// Values returns the values of key in the adding order.  Values returns nil if
+// there are none.
+func (m *BiMultiMap[K, V]) Values(key K) (values []V) {
+	if m == nil {
+		return nil
+	}
+
+	return m.values[key].Keys()
+}
+
+// Keys returns the keys of v in the adding order.  Keys returns nil if there
+// are none.
+func (m *BiMultiMap[K, V]) Keys(v V) (keys []K) {
+	if m == nil {
+		return nil
+	}
+
+	return m.keys[v].Keys()
+}
+
+// Len returns the number of pairs in m.  A nil map has a length of zero.
+func (m *BiMultiMap[K, V]) Len() (n int) {
+	if m == nil {
+		return 0
+	}
+
+	return m.len
+}
+
+// Clear removes all pairs from m.  Calling Clear on a nil map has no effect.
+func (m *BiMultiMap[K, V]) Clear() {
+	if m != nil {
+		clear(m.values)
+		clear(m.keys)
+		m.len = 0
+	}
+}
+
+// Range calls f with each pair of m until f returns false.  The order of the
+// keys is undefined, but the values of each key are in the adding order.  f
+// must not modify m.  Calling Range on a nil map has no effect.
+func (m *BiMultiMap[K, V]) Range(f func(key K, v V) (cont bool)) {
+	if m == nil {
+		return
+	}
+
+	for key, values := range m.values {
+		for v := range values.Range {
+			if !f(key, v) {
+				return
+			}
+		}
+	}
+}
+
+// RangeByKey calls f with each key of m and its values in the adding order
+// until f returns false.  The order of the keys is undefined.  f may modify
+// values but must not modify m.  Calling RangeByKey on a nil map has no effect.
+func (m *BiMultiMap[K, V]) RangeByKey(f func(key K, values []V) (cont bool)) {
+	if m == nil {
+		return
+	}
+
+	for key, values := range m.values {
+		if !f(key, values.Keys()) {
+			return
+		}
+	}
+}
+
+// RangeByValue calls f with each value of m and its keys in the adding order
+// until f returns false.  The order of the values is undefined.  f may modify
+// keys but must not modify m.  Calling RangeByValue on a nil map has no
+// effect.
+func (m *BiMultiMap[K, V]) RangeByValue(f func(v V, keys []K) (cont bool)) {
+	if m == nil {
+		return
+	}
+
+	for v, keys := range m.keys {
+		if !f(v, keys.Keys()) {
+			return
+		}
+	}
+}
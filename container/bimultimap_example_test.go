@@ -0,0 +1,39 @@
+package container_test
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/AdguardTeam/golibs/container"
+)
+
+func ExampleBiMultiMap() {
+	var (
+		addr1 = netip.MustParseAddr("192.0.2.1")
+		addr2 = netip.MustParseAddr("192.0.2.2")
+	)
+
+	m := container.NewBiMultiMap[string, netip.Addr]()
+	m.Add("client-1", addr1)
+	m.Add("client-1", addr2)
+	m.Add("client-2", addr2)
+
+	// Adding an existing pair doesn't change its position.
+	fmt.Println(m.Add("client-1", addr1), m.Len())
+
+	fmt.Println(m.Values("client-1"))
+	fmt.Println(m.Keys(addr2))
+
+	fmt.Println(m.Delete("client-1", addr2), m.Delete("client-1", addr2))
+	fmt.Println(m.Keys(addr2))
+
+	fmt.Println(m.DeleteValue(addr2), m.HasKey("client-2"), m.Len())
+
+	// Output:
+	// false 3
+	// [192.0.2.1 192.0.2.2]
+	// [client-1 client-2]
+	// true false
+	// [client-2]
+	// 1 false 1
+}
@@ -0,0 +1,161 @@
+package container_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBiMultiMapSize is the number of distinct keys and values in tests.
+const testBiMultiMapSize = 20
+
+// biMultiMapPair is a pair of a [container.BiMultiMap] for tests.
+type biMultiMapPair = container.KeyValue[int, int]
+
+// applyRandomBiMultiMapOp applies a random operation to both m and pairs, which
+// is the oracle containing the pairs in the adding order, and returns the new
+// pairs.
+func applyRandomBiMultiMapOp(
+	tb testing.TB,
+	rng *rand.Rand,
+	m *container.BiMultiMap[int, int],
+	pairs []biMultiMapPair,
+) (res []biMultiMapPair) {
+	tb.Helper()
+
+	p := biMultiMapPair{Key: rng.IntN(testBiMultiMapSize), Value: rng.IntN(testBiMultiMapSize)}
+	prevLen := len(pairs)
+
+	switch rng.IntN(10) {
+	case 0:
+		pairs = slices.DeleteFunc(pairs, func(q biMultiMapPair) (ok bool) { return q.Key == p.Key })
+		require.Equal(tb, prevLen-len(pairs), m.DeleteKey(p.Key))
+	case 1:
+		pairs = slices.DeleteFunc(pairs, func(q biMultiMapPair) (ok bool) {
+			return q.Value == p.Value
+		})
+		require.Equal(tb, prevLen-len(pairs), m.DeleteValue(p.Value))
+	case 2, 3, 4:
+		pairs = slices.DeleteFunc(pairs, func(q biMultiMapPair) (ok bool) { return q == p })
+		require.Equal(tb, prevLen != len(pairs), m.Delete(p.Key, p.Value))
+	default:
+		added := !slices.Contains(pairs, p)
+		if added {
+			pairs = append(pairs, p)
+		}
+
+		require.Equal(tb, added, m.Add(p.Key, p.Value))
+	}
+
+	require.Equal(tb, len(pairs), m.Len())
+
+	return pairs
+}
+
+// biMultiMapValuesAndKeys returns the values of the key i and the keys of the
+// value i from pairs in their order.
+func biMultiMapValuesAndKeys(pairs []biMultiMapPair, i int) (values, keys []int) {
+	for _, p := range pairs {
+		if p.Key == i {
+			values = append(values, p.Value)
+		}
+
+		if p.Value == i {
+			keys = append(keys, p.Key)
+		}
+	}
+
+	return values, keys
+}
+
+func TestBiMultiMap(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	m := container.NewBiMultiMap[int, int]()
+
+	var pairs []biMultiMapPair
+	for range 5_000 {
+		pairs = applyRandomBiMultiMapOp(t, rng, m, pairs)
+	}
+
+	for i := range testBiMultiMapSize {
+		wantValues, wantKeys := biMultiMapValuesAndKeys(pairs, i)
+		assert.Equal(t, wantValues, m.Values(i))
+		assert.Equal(t, wantValues != nil, m.HasKey(i))
+		assert.Equal(t, wantKeys, m.Keys(i))
+		assert.Equal(t, wantKeys != nil, m.HasValue(i))
+	}
+
+	var got []biMultiMapPair
+	for k, v := range m.Range {
+		require.True(t, m.Has(k, v))
+
+		got = append(got, biMultiMapPair{Key: k, Value: v})
+	}
+
+	assert.ElementsMatch(t, pairs, got)
+
+	m.Clear()
+	assert.Zero(t, m.Len())
+	assert.Nil(t, m.Values(0))
+}
+
+func TestBiMultiMap_range(t *testing.T) {
+	t.Parallel()
+
+	m := container.NewBiMultiMap[string, int]()
+	m.Add("a", 2)
+	m.Add("a", 1)
+	m.Add("b", 1)
+
+	byKey := map[string][]int{}
+	for k, values := range m.RangeByKey {
+		byKey[k] = values
+	}
+
+	assert.Equal(t, map[string][]int{"a": {2, 1}, "b": {1}}, byKey)
+
+	byValue := map[int][]string{}
+	for v, keys := range m.RangeByValue {
+		byValue[v] = keys
+	}
+
+	assert.Equal(t, map[int][]string{1: {"a", "b"}, 2: {"a"}}, byValue)
+
+	n := 0
+	for range m.Range {
+		n++
+
+		break
+	}
+
+	assert.Equal(t, 1, n)
+}
+
+func TestBiMultiMap_nil(t *testing.T) {
+	t.Parallel()
+
+	var m *container.BiMultiMap[string, int]
+
+	assert.False(t, m.Has("a", 1))
+	assert.False(t, m.HasKey("a"))
+	assert.False(t, m.HasValue(1))
+	assert.False(t, m.Delete("a", 1))
+	assert.Zero(t, m.DeleteKey("a"))
+	assert.Zero(t, m.DeleteValue(1))
+	assert.Nil(t, m.Values("a"))
+	assert.Nil(t, m.Keys(1))
+	assert.Zero(t, m.Len())
+
+	assert.NotPanics(t, m.Clear)
+	assert.NotPanics(t, func() {
+		m.Range(func(_ string, _ int) (cont bool) { panic("unexpected call") })
+		m.RangeByKey(func(_ string, _ []int) (cont bool) { panic("unexpected call") })
+		m.RangeByValue(func(_ int, _ []string) (cont bool) { panic("unexpected call") })
+	})
+}
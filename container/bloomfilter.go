@@ -0,0 +1,205 @@
+package container
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/AdguardTeam/golibs/validate"
+)
+
+// ErrBloomFilterData is returned by [BloomFilter.UnmarshalBinary] when the data
+// is not a valid serialized Bloom filter.
+const ErrBloomFilterData errors.Error = "bad bloom filter data"
+
+// Bloom filter serialization parameters.
+const (
+	// bloomFilterVersion is the version of the serialization format.
+	bloomFilterVersion byte = 1
+
+	// bloomFilterHeaderLen is the length of the serialized header: the version,
+	// the number of hashes, and the number of bits.
+	bloomFilterHeaderLen = 1 + 1 + 8
+
+	// bloomFilterMaxHashes is the maximum number of hash functions.
+	bloomFilterMaxHashes = 32
+)
+
+// BloomFilterConfig is the configuration structure for a [BloomFilter].
+type BloomFilterConfig struct {
+	// ExpectedCount is the expected number of keys in the filter.  It must be
+	// positive.
+	ExpectedCount uint
+
+	// FalsePositiveRate is the desired probability of false positives when the
+	// filter contains ExpectedCount keys.  It must be greater than zero and
+	// less than one.
+	FalsePositiveRate float64
+}
+
+// BloomFilter is a probabilistic set of strings.  [BloomFilter.Has] never
+// returns false for an added key, but may return true for a key that has not
+// been added.  Keys cannot be deleted.  A BloomFilter must be initialized with
+// [NewBloomFilter] or [BloomFilter.UnmarshalBinary].  It is not safe for
+// concurrent use.
+//
+// The hash function doesn't depend on the process, so a serialized filter can
+// be used by other processes.
+type BloomFilter struct {
+	words []uint64
+
+	// mask is used to get the index of a bit.
+	mask uint64
+
+	// hashes is the number of hash functions.
+	hashes uint8
+}
+
+// NewBloomFilter returns a new properly initialized *BloomFilter.  c must not
+// be nil and must be valid.
+func NewBloomFilter(c *BloomFilterConfig) (f *BloomFilter, err error) {
+	err = validate.NotNil("c", c)
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return nil, err
+	}
+
+	err = errors.Join(
+		validate.Positive("c.ExpectedCount", c.ExpectedCount),
+		validate.GreaterThan("c.FalsePositiveRate", c.FalsePositiveRate, 0),
+		validate.LessThan("c.FalsePositiveRate", c.FalsePositiveRate, 1),
+	)
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return nil, err
+	}
+
+	n := float64(c.ExpectedCount)
+	optimalBits := -n * math.Log(c.FalsePositiveRate) / (math.Ln2 * math.Ln2)
+
+	// Round the number of bits up to a power of two, so that a mask can be used
+	// instead of a division.
+	numBits := uint64(1) << bits.Len64(max(uint64(math.Ceil(optimalBits)), 64)-1)
+	hashes := math.Round(float64(numBits) / n * math.Ln2)
+
+	return &BloomFilter{
+		words:  make([]uint64, numBits/64),
+		mask:   numBits - 1,
+		hashes: uint8(min(max(hashes, 1), bloomFilterMaxHashes)),
+	}, nil
+}
+
+// bloomHashes returns two hashes of key for double hashing.
+func bloomHashes(key string) (h1, h2 uint64) {
+	// Use FNV-1a, since it doesn't depend on the process.
+	h := uint64(14695981039346656037)
+	for i := range len(key) {
+		h ^= uint64(key[i])
+		h *= 1099511628211
+	}
+
+	// Improve the mixing of the high bits using the finalizer of SplitMix64.
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+
+	return h, bits.RotateLeft64(h, 32) | 1
+}
+
+// Add adds key to f.
+func (f *BloomFilter) Add(key string) {
+	h1, h2 := bloomHashes(key)
+	for i := range uint64(f.hashes) {
+		idx := (h1 + i*h2) & f.mask
+		f.words[idx/64] |= 1 << (idx % 64)
+	}
+}
+
+// Has returns true if key has probably been added to f and false if it
+// definitely hasn't.
+func (f *BloomFilter) Has(key string) (ok bool) {
+	h1, h2 := bloomHashes(key)
+	for i := range uint64(f.hashes) {
+		idx := (h1 + i*h2) & f.mask
+		if f.words[idx/64]&(1<<(idx%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Clear removes all keys from f.
+func (f *BloomFilter) Clear() {
+	clear(f.words)
+}
+
+// type check
+var _ encoding.BinaryMarshaler = (*BloomFilter)(nil)
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface for
+// *BloomFilter.
+func (f *BloomFilter) MarshalBinary() (b []byte, err error) {
+	b = make([]byte, 0, bloomFilterHeaderLen+8*len(f.words))
+	b = append(b, bloomFilterVersion, f.hashes)
+	b = binary.BigEndian.AppendUint64(b, f.mask+1)
+	for _, w := range f.words {
+		b = binary.BigEndian.AppendUint64(b, w)
+	}
+
+	return b, nil
+}
+
+// type check
+var _ encoding.BinaryUnmarshaler = (*BloomFilter)(nil)
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface for
+// *BloomFilter.  Any error returned has [ErrBloomFilterData] as the underlying
+// error.
+func (f *BloomFilter) UnmarshalBinary(b []byte) (err error) {
+	if len(b) < bloomFilterHeaderLen {
+		return fmt.Errorf("%w: header: too short: %d bytes", ErrBloomFilterData, len(b))
+	}
+
+	if v := b[0]; v != bloomFilterVersion {
+		return fmt.Errorf("%w: version: unsupported: %d", ErrBloomFilterData, v)
+	}
+
+	hashes := b[1]
+	if hashes == 0 || hashes > bloomFilterMaxHashes {
+		return fmt.Errorf("%w: hashes: out of range: %d", ErrBloomFilterData, hashes)
+	}
+
+	numBits := binary.BigEndian.Uint64(b[2:])
+	if numBits < 64 || numBits&(numBits-1) != 0 {
+		return fmt.Errorf("%w: bits: not a power of two: %d", ErrBloomFilterData, numBits)
+	}
+
+	data := b[bloomFilterHeaderLen:]
+	if uint64(len(data)) != numBits/8 {
+		return fmt.Errorf(
+			"%w: data: bad length %d for %d bits",
+			ErrBloomFilterData,
+			len(data),
+			numBits,
+		)
+	}
+
+	words := make([]uint64, numBits/64)
+	for i := range words {
+		words[i] = binary.BigEndian.Uint64(data[i*8:])
+	}
+
+	*f = BloomFilter{
+		words:  words,
+		mask:   numBits - 1,
+		hashes: hashes,
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package container_test
+
+import (
+	"fmt"
+
+	"github.com/AdguardTeam/golibs/container"
+)
+
+func ExampleBloomFilter() {
+	f, err := container.NewBloomFilter(&container.BloomFilterConfig{
+		ExpectedCount:     1_000,
+		FalsePositiveRate: 0.001,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	f.Add("blocked.example")
+	f.Add("ads.example")
+
+	// Only the domains that may be in the blocklist need a precise check.
+	fmt.Println(f.Has("blocked.example"))
+	fmt.Println(f.Has("allowed.example"))
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+
+	restored := &container.BloomFilter{}
+	err = restored.UnmarshalBinary(b)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(restored.Has("ads.example"))
+
+	// Output:
+	// true
+	// false
+	// true
+}
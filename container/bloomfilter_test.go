@@ -0,0 +1,175 @@
+package container_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/container"
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testFalsePositiveRate is the false positive rate for tests.
+const testFalsePositiveRate = 0.01
+
+// newTestBloomFilter returns a new Bloom filter for n keys with
+// [testFalsePositiveRate].
+func newTestBloomFilter(tb testing.TB, n uint) (f *container.BloomFilter) {
+	tb.Helper()
+
+	f, err := container.NewBloomFilter(&container.BloomFilterConfig{
+		ExpectedCount:     n,
+		FalsePositiveRate: testFalsePositiveRate,
+	})
+	require.NoError(tb, err)
+
+	return f
+}
+
+func TestBloomFilter(t *testing.T) {
+	t.Parallel()
+
+	const n = 10_000
+
+	f := newTestBloomFilter(t, n)
+	for i := range n {
+		f.Add("added-" + strconv.Itoa(i))
+	}
+
+	for i := range n {
+		require.True(t, f.Has("added-"+strconv.Itoa(i)))
+	}
+
+	falsePositives := 0
+	for i := range n {
+		if f.Has("other-" + strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+
+	assert.LessOrEqual(t, float64(falsePositives)/n, 2*testFalsePositiveRate)
+
+	f.Clear()
+	assert.False(t, f.Has("added-0"))
+}
+
+func TestBloomFilter_MarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	f := newTestBloomFilter(t, 100)
+	f.Add("example.com")
+
+	b, err := f.MarshalBinary()
+	require.NoError(t, err)
+
+	got := &container.BloomFilter{}
+	err = got.UnmarshalBinary(b)
+	require.NoError(t, err)
+
+	assert.Equal(t, f, got)
+	assert.True(t, got.Has("example.com"))
+
+	testCases := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{{
+		name:    "short",
+		data:    b[:5],
+		wantErr: "bad bloom filter data: header: too short: 5 bytes",
+	}, {
+		name:    "version",
+		data:    append([]byte{2}, b[1:]...),
+		wantErr: "bad bloom filter data: version: unsupported: 2",
+	}, {
+		name:    "hashes",
+		data:    append([]byte{b[0], 0}, b[2:]...),
+		wantErr: "bad bloom filter data: hashes: out of range: 0",
+	}, {
+		name:    "bits",
+		data:    append([]byte{b[0], b[1], 0, 0, 0, 0, 0, 0, 0, 100}, b[10:]...),
+		wantErr: "bad bloom filter data: bits: not a power of two: 100",
+	}, {
+		name:    "data",
+		data:    b[:len(b)-1],
+		wantErr: fmt.Sprintf("bad bloom filter data: data: bad length %d for 1024 bits", len(b)-11),
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			unmarshalErr := (&container.BloomFilter{}).UnmarshalBinary(tc.data)
+			assert.ErrorIs(t, unmarshalErr, container.ErrBloomFilterData)
+			assert.EqualError(t, unmarshalErr, tc.wantErr)
+		})
+	}
+}
+
+func TestNewBloomFilter_error(t *testing.T) {
+	t.Parallel()
+
+	_, err := container.NewBloomFilter(nil)
+	assert.ErrorIs(t, err, errors.ErrNoValue)
+
+	_, err = container.NewBloomFilter(&container.BloomFilterConfig{
+		FalsePositiveRate: 1,
+	})
+	require.Error(t, err)
+
+	assert.ErrorContains(t, err, "c.ExpectedCount")
+	assert.ErrorContains(t, err, "c.FalsePositiveRate")
+}
+
+// benchBloomFilterCount is the number of keys in the Bloom filter benchmarks.
+const benchBloomFilterCount = 100_000
+
+func BenchmarkBloomFilter_Add(b *testing.B) {
+	f := newTestBloomFilter(b, benchBloomFilterCount)
+	keys := newRandStrs(benchBloomFilterCount, randStrLen)
+
+	var i int
+
+	b.ReportAllocs()
+	for b.Loop() {
+		f.Add(keys[i%benchBloomFilterCount])
+		i++
+	}
+
+	// Most recent results:
+	//	goos: linux
+	//	goarch: amd64
+	//	pkg: github.com/AdguardTeam/golibs/container
+	//	cpu: Intel(R) Xeon(R) Processor
+	//	BenchmarkBloomFilter_Add    	50936827	        22.92 ns/op	       0 B/op	       0 allocs/op
+}
+
+func BenchmarkBloomFilter_Has(b *testing.B) {
+	f := newTestBloomFilter(b, benchBloomFilterCount)
+	keys := newRandStrs(benchBloomFilterCount, randStrLen)
+	for _, k := range keys {
+		f.Add(k)
+	}
+
+	var (
+		i  int
+		ok bool
+	)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		ok = f.Has(keys[i%benchBloomFilterCount])
+		i++
+	}
+
+	assert.True(b, ok)
+
+	// Most recent results:
+	//	goos: linux
+	//	goarch: amd64
+	//	pkg: github.com/AdguardTeam/golibs/container
+	//	cpu: Intel(R) Xeon(R) Processor
+	//	BenchmarkBloomFilter_Has    	53010408	        31.86 ns/op	       0 B/op	       0 allocs/op
+}
@@ -0,0 +1,132 @@
+package container
+
+import (
+	"context"
+	"sync"
+
+	"github.com/AdguardTeam/golibs/errors"
+)
+
+// ErrQueueClosed is returned by the methods of a [BoundedQueue] that has been
+// closed.
+const ErrQueueClosed errors.Error = "queue closed"
+
+// BoundedQueue is a first-in-first-out queue with a fixed capacity that is
+// safe for concurrent use.  Unlike a [RingBuffer], it never overwrites its
+// elements; instead, [BoundedQueue.Push] blocks until there is space for a new
+// element, which makes it suitable for producer-consumer pipelines.
+type BoundedQueue[T any] struct {
+	elems chan T
+
+	// done is closed when the queue is closed.
+	done chan unit
+
+	closeOnce *sync.Once
+}
+
+// NewBoundedQueue returns a new queue that can contain up to size elements.  If
+// size is zero, each call to [BoundedQueue.Push] blocks until the element is
+// received by [BoundedQueue.Pop].
+func NewBoundedQueue[T any](size uint) (q *BoundedQueue[T]) {
+	return &BoundedQueue[T]{
+		elems:     make(chan T, size),
+		done:      make(chan unit),
+		closeOnce: &sync.Once{},
+	}
+}
+
+// Push adds e to the back of q, waiting for space if q is full.  It returns
+// [ErrQueueClosed] if q is closed or the error of ctx if ctx is done before
+// the element is added.
+func (q *BoundedQueue[T]) Push(ctx context.Context, e T) (err error) {
+	select {
+	case <-q.done:
+		return ErrQueueClosed
+	default:
+		// Go on.
+	}
+
+	select {
+	case q.elems <- e:
+		return nil
+	case <-q.done:
+		return ErrQueueClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TryPush adds e to the back of q if q is not full and not closed, and returns
+// true if e has been added.
+func (q *BoundedQueue[T]) TryPush(e T) (ok bool) {
+	select {
+	case <-q.done:
+		return false
+	default:
+		// Go on.
+	}
+
+	select {
+	case q.elems <- e:
+		return true
+	default:
+		return false
+	}
+}
+
+// Pop removes and returns the element at the front of q, waiting for an
+// element if q is empty.  Once q is closed, Pop returns the remaining elements
+// and then [ErrQueueClosed].  It returns the error of ctx if ctx is done before
+// an element is received.
+func (q *BoundedQueue[T]) Pop(ctx context.Context) (e T, err error) {
+	select {
+	case e = <-q.elems:
+		return e, nil
+	case <-q.done:
+		return q.popClosed()
+	case <-ctx.Done():
+		return e, ctx.Err()
+	}
+}
+
+// popClosed returns a remaining element of a closed queue or
+// [ErrQueueClosed] if there are none.
+func (q *BoundedQueue[T]) popClosed() (e T, err error) {
+	select {
+	case e = <-q.elems:
+		return e, nil
+	default:
+		return e, ErrQueueClosed
+	}
+}
+
+// TryPop removes and returns the element at the front of q if q is not empty.
+// ok is false if q is empty.
+func (q *BoundedQueue[T]) TryPop() (e T, ok bool) {
+	select {
+	case e = <-q.elems:
+		return e, true
+	default:
+		return e, false
+	}
+}
+
+// Len returns the number of elements in q.
+func (q *BoundedQueue[T]) Len() (n int) {
+	return len(q.elems)
+}
+
+// Cap returns the maximum number of elements in q.
+func (q *BoundedQueue[T]) Cap() (n int) {
+	return cap(q.elems)
+}
+
+// Close closes q, so that new elements are not added and the waiting calls to
+// [BoundedQueue.Push] return [ErrQueueClosed].  The elements that are already
+// in q can still be received with [BoundedQueue.Pop].  Calling Close several
+// times has no effect.
+func (q *BoundedQueue[T]) Close() {
+	q.closeOnce.Do(func() {
+		close(q.done)
+	})
+}
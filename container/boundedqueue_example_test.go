@@ -0,0 +1,41 @@
+package container_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AdguardTeam/golibs/container"
+)
+
+func ExampleBoundedQueue() {
+	ctx := context.Background()
+	q := container.NewBoundedQueue[int](2)
+
+	go func() {
+		defer q.Close()
+
+		for i := range 5 {
+			// Push blocks while the queue is full.
+			_ = q.Push(ctx, i)
+		}
+	}()
+
+	for {
+		e, err := q.Pop(ctx)
+		if err != nil {
+			fmt.Println(err)
+
+			break
+		}
+
+		fmt.Println(e)
+	}
+
+	// Output:
+	// 0
+	// 1
+	// 2
+	// 3
+	// 4
+	// queue closed
+}
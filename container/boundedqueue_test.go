@@ -0,0 +1,115 @@
+package container_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/container"
+	"github.com/AdguardTeam/golibs/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoundedQueue(t *testing.T) {
+	t.Parallel()
+
+	q := container.NewBoundedQueue[int](2)
+	assert.Equal(t, 2, q.Cap())
+
+	ctx := testutil.ContextWithTimeout(t, testTimeout)
+
+	require.NoError(t, q.Push(ctx, 1))
+	require.True(t, q.TryPush(2))
+	assert.False(t, q.TryPush(3))
+	assert.Equal(t, 2, q.Len())
+
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	err := q.Push(canceledCtx, 3)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	e, err := q.Pop(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, e)
+
+	e, ok := q.TryPop()
+	require.True(t, ok)
+	assert.Equal(t, 2, e)
+
+	_, ok = q.TryPop()
+	assert.False(t, ok)
+
+	_, err = q.Pop(canceledCtx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestBoundedQueue_Close(t *testing.T) {
+	t.Parallel()
+
+	q := container.NewBoundedQueue[int](1)
+	ctx := testutil.ContextWithTimeout(t, testTimeout)
+
+	require.NoError(t, q.Push(ctx, 1))
+
+	pushErrCh := make(chan error, 1)
+	go func() {
+		pushErrCh <- q.Push(ctx, 2)
+	}()
+
+	q.Close()
+	q.Close()
+
+	pushErr, _ := testutil.RequireReceive(t, pushErrCh, testTimeout)
+	assert.ErrorIs(t, pushErr, container.ErrQueueClosed)
+
+	assert.ErrorIs(t, q.Push(ctx, 3), container.ErrQueueClosed)
+	assert.False(t, q.TryPush(3))
+
+	// The remaining element is still returned.
+	e, err := q.Pop(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, e)
+
+	_, err = q.Pop(ctx)
+	assert.ErrorIs(t, err, container.ErrQueueClosed)
+}
+
+func TestBoundedQueue_pipeline(t *testing.T) {
+	t.Parallel()
+
+	const num = 1_000
+
+	q := container.NewBoundedQueue[int](4)
+	ctx := testutil.ContextWithTimeout(t, testTimeout)
+
+	wg := &sync.WaitGroup{}
+	wg.Go(func() {
+		defer q.Close()
+
+		for i := range num {
+			assert.NoError(t, q.Push(ctx, i))
+		}
+	})
+
+	var got []int
+	for {
+		e, err := q.Pop(ctx)
+		if err != nil {
+			require.ErrorIs(t, err, container.ErrQueueClosed)
+
+			break
+		}
+
+		got = append(got, e)
+	}
+
+	wg.Wait()
+
+	require.Len(t, got, num)
+
+	for i, e := range got {
+		assert.Equal(t, i, e)
+	}
+}
@@ -0,0 +1,279 @@
+package container
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/AdguardTeam/golibs/errors"
+	"golang.org/x/exp/constraints"
+)
+
+// ClosedInterval is a closed interval of values, [First, Last].  Unlike
+// [Interval], it can contain the maximum value of T.  An interval with Last
+// less than First is empty.
+type ClosedInterval[T any] struct {
+	// First is the first value of the interval.
+	First T
+
+	// Last is the last value of the interval.
+	Last T
+}
+
+// type check
+var _ fmt.Stringer = ClosedInterval[int]{}
+
+// String implements the [fmt.Stringer] interface for ClosedInterval.
+func (iv ClosedInterval[T]) String() (s string) {
+	return fmt.Sprintf("[%v, %v]", iv.First, iv.Last)
+}
+
+// Discrete is the interface for types of values that have a total order and
+// in which every value except the maximum one has a successor and every value
+// except the minimum one has a predecessor, such as [netip.Addr].
+type Discrete[T any] interface {
+	// Compare returns -1 if the value is less than other, +1 if it is greater
+	// than other, and 0 if they are equal.
+	Compare(other T) (res int)
+
+	// Next returns the value right after the receiver.  It is never called on
+	// the maximum value.
+	Next() (next T)
+
+	// Prev returns the value right before the receiver.  It is never called on
+	// the minimum value.
+	Prev() (prev T)
+}
+
+// closedOps are the [intervalOps] for closed intervals of discrete values.
+type closedOps[T any] struct {
+	cmp  func(a, b T) (res int)
+	next func(v T) (next T)
+	prev func(v T) (prev T)
+}
+
+// type check
+var _ intervalOps[int, ClosedInterval[int]] = closedOps[int]{}
+
+// bounds implements the [intervalOps] interface for closedOps.
+func (closedOps[T]) bounds(iv ClosedInterval[T]) (lo, hi T) { return iv.First, iv.Last }
+
+// interval implements the [intervalOps] interface for closedOps.
+func (closedOps[T]) interval(lo, hi T) (iv ClosedInterval[T]) {
+	return ClosedInterval[T]{First: lo, Last: hi}
+}
+
+// compare implements the [intervalOps] interface for closedOps.
+func (ops closedOps[T]) compare(a, b T) (res int) { return ops.cmp(a, b) }
+
+// isEmpty implements the [intervalOps] interface for closedOps.
+func (ops closedOps[T]) isEmpty(iv ClosedInterval[T]) (ok bool) {
+	return ops.cmp(iv.Last, iv.First) < 0
+}
+
+// endsBefore implements the [intervalOps] interface for closedOps.
+func (ops closedOps[T]) endsBefore(iv ClosedInterval[T], v T) (ok bool) {
+	return ops.cmp(iv.Last, v) < 0
+}
+
+// isSeparate implements the [intervalOps] interface for closedOps.  The
+// successor is only calculated for a value less than some other value, so it
+// always exists.
+func (ops closedOps[T]) isSeparate(a, b ClosedInterval[T]) (ok bool) {
+	return ops.cmp(a.Last, b.First) < 0 && ops.cmp(ops.next(a.Last), b.First) < 0
+}
+
+// head implements the [intervalOps] interface for closedOps.  Since b.First is
+// greater than a.First, its predecessor exists.
+func (ops closedOps[T]) head(a, b ClosedInterval[T]) (iv ClosedInterval[T]) {
+	return ClosedInterval[T]{First: a.First, Last: ops.prev(b.First)}
+}
+
+// tail implements the [intervalOps] interface for closedOps.  Since b.Last is
+// less than a.Last, its successor exists.
+func (ops closedOps[T]) tail(a, b ClosedInterval[T]) (iv ClosedInterval[T]) {
+	return ClosedInterval[T]{First: ops.next(b.Last), Last: a.Last}
+}
+
+// ClosedRangeSet is like [RangeSet], but stores closed intervals of discrete
+// values, such as integers or IP addresses, and can therefore contain the
+// maximum value of T.  Overlapping and adjacent intervals are merged.  Lookups
+// take O(log n) time, where n is the number of intervals.  It must be
+// initialized with [NewClosedRangeSet] or [NewIntegerRangeSet].
+type ClosedRangeSet[T any] struct {
+	core intervalSet[T, ClosedInterval[T], closedOps[T]]
+}
+
+// NewClosedRangeSet returns a new *ClosedRangeSet containing the values from
+// ivs.  For [netip.Addr], an interval must not contain addresses of both
+// families, since the last IPv4 address has no successor and the first IPv6
+// address has no predecessor.
+func NewClosedRangeSet[T Discrete[T]](ivs ...ClosedInterval[T]) (set *ClosedRangeSet[T]) {
+	return newClosedRangeSet(closedOps[T]{
+		cmp:  func(a, b T) (res int) { return a.Compare(b) },
+		next: func(v T) (next T) { return v.Next() },
+		prev: func(v T) (prev T) { return v.Prev() },
+	}, ivs)
+}
+
+// NewIntegerRangeSet returns a new *ClosedRangeSet of integers containing the
+// values from ivs.
+func NewIntegerRangeSet[T constraints.Integer](ivs ...ClosedInterval[T]) (set *ClosedRangeSet[T]) {
+	return newClosedRangeSet(closedOps[T]{
+		cmp:  cmp.Compare[T],
+		next: func(v T) (next T) { return v + 1 },
+		prev: func(v T) (prev T) { return v - 1 },
+	}, ivs)
+}
+
+// newClosedRangeSet returns a new *ClosedRangeSet with the given operations
+// containing the values from ivs.
+func newClosedRangeSet[T any](ops closedOps[T], ivs []ClosedInterval[T]) (set *ClosedRangeSet[T]) {
+	set = &ClosedRangeSet[T]{
+		core: intervalSet[T, ClosedInterval[T], closedOps[T]]{
+			ops: ops,
+		},
+	}
+
+	for _, iv := range ivs {
+		set.Add(iv)
+	}
+
+	return set
+}
+
+// Add adds the values from iv to set, merging the intervals as necessary.  set
+// must not be nil.
+func (set *ClosedRangeSet[T]) Add(iv ClosedInterval[T]) {
+	set.core.add(iv)
+}
+
+// Delete deletes the values from iv from set, splitting the intervals as
+// necessary.  Calling Delete on a nil set has no effect.
+func (set *ClosedRangeSet[T]) Delete(iv ClosedInterval[T]) {
+	if set != nil {
+		set.core.delete(iv)
+	}
+}
+
+// Has returns true if v is in set.  Calling Has on a nil set returns false.
+func (set *ClosedRangeSet[T]) Has(v T) (ok bool) {
+	_, ok = set.Find(v)
+
+	return ok
+}
+
+// Find returns the interval of set that contains v.  Since the intervals are
+// merged, there is at most one such interval.  Calling Find on a nil set
+// returns false.
+func (set *ClosedRangeSet[T]) Find(v T) (iv ClosedInterval[T], ok bool) {
+	if set == nil {
+		return iv, false
+	}
+
+	return set.core.find(v)
+}
+
+// HasInterval returns true if all values from iv are in set.  An empty interval
+// is in any non-nil set.  Calling HasInterval on a nil set returns false.
+func (set *ClosedRangeSet[T]) HasInterval(iv ClosedInterval[T]) (ok bool) {
+	return set != nil && set.core.hasInterval(iv)
+}
+
+// Overlaps returns true if set has at least one value from iv.  Calling
+// Overlaps on a nil set returns false.
+func (set *ClosedRangeSet[T]) Overlaps(iv ClosedInterval[T]) (ok bool) {
+	return set != nil && set.core.overlaps(iv)
+}
+
+// Clear clears set in a way that retains the internal storage for later reuse
+// to reduce allocations.  Calling Clear on a nil set has no effect.
+func (set *ClosedRangeSet[T]) Clear() {
+	if set != nil {
+		set.core.clear()
+	}
+}
+
+// Clone returns a deep clone of set.  If set is nil, clone is nil.
+func (set *ClosedRangeSet[T]) Clone() (clone *ClosedRangeSet[T]) {
+	if set == nil {
+		return nil
+	}
+
+	return &ClosedRangeSet[T]{
+		core: set.core.clone(),
+	}
+}
+
+// Equal returns true if set is equal to other.  set and other may be nil;
+// Equal returns true if both are nil, but a nil *ClosedRangeSet is not equal
+// to a non-nil empty one.
+func (set *ClosedRangeSet[T]) Equal(other *ClosedRangeSet[T]) (ok bool) {
+	if set == nil || other == nil {
+		return set == other
+	}
+
+	return set.core.equal(&other.core)
+}
+
+// Len returns the number of the intervals in set, not the number of values.  A
+// nil set has a length of zero.
+func (set *ClosedRangeSet[T]) Len() (n int) {
+	return len(set.Intervals())
+}
+
+// Range calls f with each interval of set in the ascending order until f
+// returns false.  f must not modify set.  Calling Range on a nil set has no
+// effect.
+func (set *ClosedRangeSet[T]) Range(f func(iv ClosedInterval[T]) (cont bool)) {
+	for _, iv := range set.Intervals() {
+		if !f(iv) {
+			return
+		}
+	}
+}
+
+// type check
+var _ fmt.Stringer = (*ClosedRangeSet[int])(nil)
+
+// String implements the [fmt.Stringer] interface for *ClosedRangeSet.
+func (set *ClosedRangeSet[T]) String() (s string) {
+	return fmt.Sprintf("%v", set.Intervals())
+}
+
+// Intervals returns the underlying slice of intervals.  The slice must not be
+// modified.  Intervals returns nil if set is nil.
+func (set *ClosedRangeSet[T]) Intervals() (ivs []ClosedInterval[T]) {
+	if set == nil {
+		return nil
+	}
+
+	return set.core.ivs
+}
+
+// Union fills set with values belonging to either a or b.  set must not be nil.
+// Union returns an empty set if both a and b are nil.  If neither a nor b are
+// equal to set, then the function will rewrite the contents of set; otherwise,
+// it allocates new storage for set.
+func (set *ClosedRangeSet[T]) Union(a, b *ClosedRangeSet[T]) (res *ClosedRangeSet[T]) {
+	if set == nil {
+		panic(fmt.Errorf("set: %v", errors.ErrNoValue))
+	}
+
+	set.core.union(a.Intervals(), b.Intervals(), set == a || set == b)
+
+	return set
+}
+
+// Difference fills set with values belonging to a but not to b.  set must not
+// be nil.  Difference returns an empty set if a is nil.  If neither a nor b are
+// equal to set, then the function will rewrite the contents of set; otherwise,
+// it allocates new storage for set.
+func (set *ClosedRangeSet[T]) Difference(a, b *ClosedRangeSet[T]) (res *ClosedRangeSet[T]) {
+	if set == nil {
+		panic(fmt.Errorf("set: %v", errors.ErrNoValue))
+	}
+
+	set.core.difference(a.Intervals(), b.Intervals(), set == a || set == b)
+
+	return set
+}
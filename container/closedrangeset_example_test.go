@@ -0,0 +1,54 @@
+package container_test
+
+import (
+	"fmt"
+	"math"
+	"net/netip"
+
+	"github.com/AdguardTeam/golibs/container"
+)
+
+func ExampleClosedRangeSet() {
+	type portRange = container.ClosedInterval[uint16]
+
+	ports := container.NewIntegerRangeSet(
+		portRange{First: 49152, Last: math.MaxUint16},
+		portRange{First: 80, Last: 80},
+		portRange{First: 443, Last: 443},
+	)
+
+	fmt.Println(ports)
+	fmt.Println(ports.Has(80), ports.Has(8080), ports.Has(math.MaxUint16))
+
+	ports.Delete(portRange{First: 60000, Last: 60009})
+	fmt.Println(ports)
+
+	// Output:
+	// [[80, 80] [443, 443] [49152, 65535]]
+	// true false true
+	// [[80, 80] [443, 443] [49152, 59999] [60010, 65535]]
+}
+
+func ExampleNewClosedRangeSet() {
+	type addrRange = container.ClosedInterval[netip.Addr]
+
+	addrs := container.NewClosedRangeSet(
+		addrRange{
+			First: netip.MustParseAddr("192.0.2.1"),
+			Last:  netip.MustParseAddr("192.0.2.10"),
+		},
+		addrRange{
+			First: netip.MustParseAddr("192.0.2.11"),
+			Last:  netip.MustParseAddr("192.0.2.20"),
+		},
+	)
+
+	fmt.Println(addrs)
+	fmt.Println(addrs.Find(netip.MustParseAddr("192.0.2.15")))
+	fmt.Println(addrs.Has(netip.MustParseAddr("192.0.2.21")))
+
+	// Output:
+	// [[192.0.2.1, 192.0.2.20]]
+	// [192.0.2.1, 192.0.2.20] true
+	// false
+}
@@ -0,0 +1,264 @@
+package container_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"net/netip"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// closedRangeOracle is a naive implementation of a closed range set of all
+// uint8 values for tests.
+type closedRangeOracle [math.MaxUint8 + 1]bool
+
+// set sets the values of iv in o to ok.
+func (o *closedRangeOracle) set(iv container.ClosedInterval[uint8], ok bool) {
+	for v := int(iv.First); v <= int(iv.Last); v++ {
+		o[v] = ok
+	}
+}
+
+// newRandClosedInterval returns a random, possibly empty, interval, which is
+// often close to the minimum or the maximum value.
+func newRandClosedInterval(rng *rand.Rand) (iv container.ClosedInterval[uint8]) {
+	first := uint8(rng.UintN(math.MaxUint8 + 1))
+	switch rng.IntN(4) {
+	case 0:
+		first = 0
+	case 1:
+		first = math.MaxUint8 - uint8(rng.UintN(8))
+	}
+
+	last := first + uint8(rng.UintN(32))
+	if last < first || rng.IntN(8) == 0 {
+		last = math.MaxUint8
+	}
+
+	if rng.IntN(16) == 0 {
+		first, last = last, first
+	}
+
+	return container.ClosedInterval[uint8]{First: first, Last: last}
+}
+
+// newRandClosedRangeSet returns a random closed range set and its oracle.
+func newRandClosedRangeSet(
+	rng *rand.Rand,
+) (set *container.ClosedRangeSet[uint8], o *closedRangeOracle) {
+	set, o = container.NewIntegerRangeSet[uint8](), &closedRangeOracle{}
+	for range rng.IntN(8) {
+		iv := newRandClosedInterval(rng)
+		if rng.IntN(3) == 0 {
+			set.Delete(iv)
+			o.set(iv, false)
+		} else {
+			set.Add(iv)
+			o.set(iv, true)
+		}
+	}
+
+	return set, o
+}
+
+// assertClosedRangeSet checks that set matches o and that the intervals of set
+// are sorted, non-empty, and neither overlap nor touch.
+func assertClosedRangeSet(
+	tb testing.TB,
+	o *closedRangeOracle,
+	set *container.ClosedRangeSet[uint8],
+) {
+	tb.Helper()
+
+	for v, want := range o {
+		assert.Equalf(tb, want, set.Has(uint8(v)), "value %d in %s", v, set)
+	}
+
+	ivs := set.Intervals()
+	for i, iv := range ivs {
+		assert.LessOrEqual(tb, iv.First, iv.Last, "interval %d in %s", i, set)
+		if i > 0 {
+			assert.Less(tb, int(ivs[i-1].Last)+1, int(iv.First), "interval %d in %s", i, set)
+		}
+	}
+}
+
+func TestClosedRangeSet(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 500 {
+		set, o := newRandClosedRangeSet(rng)
+		assertClosedRangeSet(t, o, set)
+
+		iv := newRandClosedInterval(rng)
+
+		wantHas, wantOverlaps := true, false
+		for v := int(iv.First); v <= int(iv.Last); v++ {
+			wantHas = wantHas && o[v]
+			wantOverlaps = wantOverlaps || o[v]
+		}
+
+		assert.Equal(t, wantHas, set.HasInterval(iv), "%s in %s", iv, set)
+		assert.Equal(t, wantOverlaps, set.Overlaps(iv), "%s in %s", iv, set)
+
+		clone := set.Clone()
+		assert.True(t, clone.Equal(set), "clone of %s", set)
+
+		clone.Add(iv)
+		assert.Equal(t, wantHas, clone.Equal(set), "%s with %s", set, iv)
+	}
+}
+
+func TestClosedRangeSet_max(t *testing.T) {
+	t.Parallel()
+
+	set := container.NewIntegerRangeSet(
+		container.ClosedInterval[uint8]{First: 250, Last: math.MaxUint8},
+		container.ClosedInterval[uint8]{First: 0, Last: 0},
+	)
+	assert.True(t, set.Has(math.MaxUint8))
+	assert.True(t, set.Has(0))
+
+	set.Add(container.ClosedInterval[uint8]{First: 1, Last: 249})
+	assert.Equal(t, []container.ClosedInterval[uint8]{{First: 0, Last: math.MaxUint8}}, set.Intervals())
+
+	set.Delete(container.ClosedInterval[uint8]{First: math.MaxUint8, Last: math.MaxUint8})
+	set.Delete(container.ClosedInterval[uint8]{First: 0, Last: 0})
+	assert.Equal(t, []container.ClosedInterval[uint8]{{First: 1, Last: 254}}, set.Intervals())
+}
+
+func TestClosedRangeSet_Find(t *testing.T) {
+	t.Parallel()
+
+	set := container.NewIntegerRangeSet(
+		container.ClosedInterval[int]{First: 1, Last: 2},
+		container.ClosedInterval[int]{First: 3, Last: 4},
+		container.ClosedInterval[int]{First: 10, Last: 19},
+	)
+
+	iv, ok := set.Find(4)
+	require.True(t, ok)
+
+	assert.Equal(t, container.ClosedInterval[int]{First: 1, Last: 4}, iv)
+
+	_, ok = set.Find(5)
+	assert.False(t, ok)
+
+	var nilSet *container.ClosedRangeSet[int]
+	_, ok = nilSet.Find(4)
+	assert.False(t, ok)
+}
+
+func TestClosedRangeSet_addr(t *testing.T) {
+	t.Parallel()
+
+	type addrRange = container.ClosedInterval[netip.Addr]
+
+	set := container.NewClosedRangeSet(
+		addrRange{
+			First: netip.MustParseAddr("192.0.2.0"),
+			Last:  netip.MustParseAddr("192.0.2.127"),
+		},
+		addrRange{
+			First: netip.MustParseAddr("192.0.2.128"),
+			Last:  netip.MustParseAddr("192.0.2.255"),
+		},
+		addrRange{
+			First: netip.MustParseAddr("255.255.255.255"),
+			Last:  netip.MustParseAddr("255.255.255.255"),
+		},
+		addrRange{
+			First: netip.MustParseAddr("::"),
+			Last:  netip.MustParseAddr("::1"),
+		},
+	)
+
+	require.Equal(t, 3, set.Len())
+
+	assert.True(t, set.Has(netip.MustParseAddr("192.0.2.200")))
+	assert.True(t, set.Has(netip.MustParseAddr("255.255.255.255")))
+	assert.True(t, set.Has(netip.MustParseAddr("::1")))
+	assert.False(t, set.Has(netip.MustParseAddr("192.0.3.0")))
+	assert.False(t, set.Has(netip.MustParseAddr("::2")))
+
+	set.Delete(addrRange{
+		First: netip.MustParseAddr("192.0.2.100"),
+		Last:  netip.MustParseAddr("192.0.2.199"),
+	})
+
+	assert.Equal(t, []addrRange{{
+		First: netip.MustParseAddr("192.0.2.0"),
+		Last:  netip.MustParseAddr("192.0.2.99"),
+	}, {
+		First: netip.MustParseAddr("192.0.2.200"),
+		Last:  netip.MustParseAddr("192.0.2.255"),
+	}, {
+		First: netip.MustParseAddr("255.255.255.255"),
+		Last:  netip.MustParseAddr("255.255.255.255"),
+	}, {
+		First: netip.MustParseAddr("::"),
+		Last:  netip.MustParseAddr("::1"),
+	}}, set.Intervals())
+}
+
+// assertClosedRangeSetOp checks op with the receiver being a new set, a, and b.
+func assertClosedRangeSetOp(
+	t *testing.T,
+	op func(set, a, b *container.ClosedRangeSet[uint8]) (res *container.ClosedRangeSet[uint8]),
+	oracle func(inA, inB bool) (ok bool),
+	rng *rand.Rand,
+) {
+	t.Helper()
+
+	// Receivers: 0 is a new set, 1 is a, and 2 is b.
+	for recv := range 3 {
+		seed := rng.Uint64()
+
+		a, oa := newRandClosedRangeSet(rand.New(rand.NewPCG(seed, 1)))
+		b, ob := newRandClosedRangeSet(rand.New(rand.NewPCG(seed, 2)))
+
+		want := &closedRangeOracle{}
+		for v := range want {
+			want[v] = oracle(oa[v], ob[v])
+		}
+
+		set := []*container.ClosedRangeSet[uint8]{container.NewIntegerRangeSet[uint8](), a, b}[recv]
+		assertClosedRangeSet(t, want, op(set, a, b))
+	}
+}
+
+func TestClosedRangeSet_Union(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 100 {
+		assertClosedRangeSetOp(t, (*container.ClosedRangeSet[uint8]).Union, func(inA, inB bool) (ok bool) {
+			return inA || inB
+		}, rng)
+	}
+
+	set := container.NewIntegerRangeSet(container.ClosedInterval[int]{First: 1, Last: 1})
+	assert.Equal(t, 0, set.Union(nil, nil).Len())
+	assert.Panics(t, func() {
+		var nilSet *container.ClosedRangeSet[int]
+		nilSet.Union(set, set)
+	})
+}
+
+func TestClosedRangeSet_Difference(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 100 {
+		assertClosedRangeSetOp(t, (*container.ClosedRangeSet[uint8]).Difference, func(inA, inB bool) (ok bool) {
+			return inA && !inB
+		}, rng)
+	}
+
+	a := container.NewIntegerRangeSet(container.ClosedInterval[int]{First: 1, Last: 9})
+	assert.Equal(t, 0, a.Difference(a, a).Len())
+}
@@ -1,8 +1,4 @@
 // Package container provides common container types.
-//
-// TODO(a.garipov): Consider adding a set interface.
-//
-// TODO(a.garipov): Consider adding a intersect and union operations on sets.
 package container
 
 // unit is a convenient alias for struct{}.
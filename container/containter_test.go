@@ -11,6 +11,9 @@ const (
 	setMaxLen  = 100_000
 )
 
+// testTimeout is the common timeout for tests.
+const testTimeout = 1 * time.Second
+
 // newRandStrs returns a slice of random strings of length l with each string
 // being strLen bytes long.
 func newRandStrs(l, strLen int) (strs []string) {
@@ -0,0 +1,182 @@
+package container
+
+import (
+	"cmp"
+	"hash/maphash"
+	"math/bits"
+	"slices"
+
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/AdguardTeam/golibs/validate"
+)
+
+// CountMinSketchConfig is the configuration structure for a [CountMinSketch].
+type CountMinSketchConfig struct {
+	// Width is the number of counters in each row.  An estimate exceeds the
+	// true count by more than about 2*total/Width with a probability of about
+	// 1/2^Depth, where total is the sum of all counts.  It is rounded up to a
+	// power of two.  It must be positive.
+	Width uint
+
+	// Depth is the number of rows of counters.  It must be positive.
+	Depth uint
+
+	// TopK is the number of the keys with the highest estimates to track.  If
+	// it is zero, no keys are tracked.
+	TopK uint
+}
+
+// CountMinSketch is a probabilistic structure that estimates the counts of
+// keys and tracks the keys with the highest counts, also known as the heavy
+// hitters.  The estimates are never lower than the true counts.  A
+// CountMinSketch must be initialized with [NewCountMinSketch].  It is not safe
+// for concurrent use.
+type CountMinSketch[K comparable] struct {
+	seed     maphash.Seed
+	counters []uint64
+
+	// top contains the tracked keys with the lowest estimate at the front.
+	top *PriorityQueue[KeyValue[K, uint64]]
+
+	// topHandles are the handles of the keys in top.
+	topHandles map[K]*PriorityQueueHandle[KeyValue[K, uint64]]
+
+	// mask is used to get an index within a row.
+	mask uint64
+
+	depth uint64
+	total uint64
+	topK  uint
+}
+
+// NewCountMinSketch returns a new properly initialized *CountMinSketch.  c must
+// not be nil and must be valid.
+func NewCountMinSketch[K comparable](c *CountMinSketchConfig) (s *CountMinSketch[K], err error) {
+	err = validate.NotNil("c", c)
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return nil, err
+	}
+
+	err = errors.Join(
+		validate.Positive("c.Width", c.Width),
+		validate.Positive("c.Depth", c.Depth),
+	)
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return nil, err
+	}
+
+	width := uint64(1) << bits.Len(c.Width-1)
+
+	return &CountMinSketch[K]{
+		seed:     maphash.MakeSeed(),
+		counters: make([]uint64, uint64(c.Depth)*width),
+		top: NewPriorityQueue(func(a, b KeyValue[K, uint64]) (res int) {
+			return cmp.Compare(a.Value, b.Value)
+		}),
+		topHandles: make(map[K]*PriorityQueueHandle[KeyValue[K, uint64]], c.TopK),
+		mask:       width - 1,
+		depth:      uint64(c.Depth),
+		topK:       c.TopK,
+	}, nil
+}
+
+// index returns the index of the counter for the key with the hash h in row.
+func (s *CountMinSketch[K]) index(h, row uint64) (idx uint64) {
+	h1, h2 := h&0xffff_ffff, h>>32|1
+
+	return row*(s.mask+1) + (h1+row*h2)&s.mask
+}
+
+// estimate returns the estimate for the key with the hash h.
+func (s *CountMinSketch[K]) estimate(h uint64) (est uint64) {
+	est = s.counters[s.index(h, 0)]
+	for row := uint64(1); row < s.depth; row++ {
+		est = min(est, s.counters[s.index(h, row)])
+	}
+
+	return est
+}
+
+// Add adds n to the count of key and returns the new estimate.
+func (s *CountMinSketch[K]) Add(key K, n uint64) (est uint64) {
+	h := maphash.Comparable(s.seed, key)
+
+	// Use the conservative update, which only increases the counters that are
+	// lower than the new estimate, to reduce the error.
+	est = s.estimate(h) + n
+	for row := range s.depth {
+		idx := s.index(h, row)
+		s.counters[idx] = max(s.counters[idx], est)
+	}
+
+	s.total += n
+	s.updateTop(key, est)
+
+	return est
+}
+
+// updateTop updates the tracked keys with the new estimate of key.
+func (s *CountMinSketch[K]) updateTop(key K, est uint64) {
+	if s.topK == 0 {
+		return
+	}
+
+	kv := KeyValue[K, uint64]{
+		Key:   key,
+		Value: est,
+	}
+
+	if h, ok := s.topHandles[key]; ok {
+		s.top.Update(h, kv)
+
+		return
+	}
+
+	if uint(s.top.Len()) >= s.topK {
+		lowest, _ := s.top.Peek()
+		if lowest.Value >= est {
+			return
+		}
+
+		_, _ = s.top.Pop()
+		delete(s.topHandles, lowest.Key)
+	}
+
+	s.topHandles[key] = s.top.Push(kv)
+}
+
+// Estimate returns the estimated count of key.
+func (s *CountMinSketch[K]) Estimate(key K) (est uint64) {
+	return s.estimate(maphash.Comparable(s.seed, key))
+}
+
+// Total returns the sum of all counts added to s.
+func (s *CountMinSketch[K]) Total() (n uint64) {
+	return s.total
+}
+
+// TopK returns the tracked keys with the highest estimates, sorted by their
+// estimates in the descending order.  The estimates are the ones the keys had
+// when they were last added.
+func (s *CountMinSketch[K]) TopK() (top []KeyValue[K, uint64]) {
+	top = make([]KeyValue[K, uint64], 0, len(s.topHandles))
+	for _, h := range s.topHandles {
+		top = append(top, h.Value())
+	}
+
+	slices.SortFunc(top, func(a, b KeyValue[K, uint64]) (res int) {
+		return cmp.Compare(b.Value, a.Value)
+	})
+
+	return top
+}
+
+// Clear resets all counts and tracked keys.
+func (s *CountMinSketch[K]) Clear() {
+	clear(s.counters)
+	clear(s.topHandles)
+	s.top.Clear()
+	s.total = 0
+}
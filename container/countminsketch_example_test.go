@@ -0,0 +1,39 @@
+package container_test
+
+import (
+	"fmt"
+
+	"github.com/AdguardTeam/golibs/container"
+)
+
+func ExampleCountMinSketch() {
+	s, err := container.NewCountMinSketch[string](&container.CountMinSketchConfig{
+		Width: 1024,
+		Depth: 4,
+		TopK:  2,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	queries := map[string]uint64{
+		"a.example": 10,
+		"b.example": 30,
+		"c.example": 20,
+		"d.example": 1,
+	}
+
+	for domain, n := range queries {
+		s.Add(domain, n)
+	}
+
+	fmt.Println("total:", s.Total())
+	for _, kv := range s.TopK() {
+		fmt.Printf("%s: at least %t\n", kv.Key, kv.Value >= queries[kv.Key])
+	}
+
+	// Output:
+	// total: 61
+	// b.example: at least true
+	// c.example: at least true
+}
@@ -0,0 +1,124 @@
+package container_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/container"
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCountMinSketch returns a new count-min sketch for tests.
+func newTestCountMinSketch(tb testing.TB, topK uint) (s *container.CountMinSketch[string]) {
+	tb.Helper()
+
+	s, err := container.NewCountMinSketch[string](&container.CountMinSketchConfig{
+		Width: 1 << 14,
+		Depth: 4,
+		TopK:  topK,
+	})
+	require.NoError(tb, err)
+
+	return s
+}
+
+func TestCountMinSketch(t *testing.T) {
+	t.Parallel()
+
+	const (
+		topK     = 5
+		lightNum = 1_000
+	)
+
+	s := newTestCountMinSketch(t, topK)
+
+	// Add many light keys once and a few heavy keys many times.  Heavy key i
+	// is added 100*(i+1) times, so the order of the heavy hitters is known.
+	var total uint64
+	for i := range lightNum {
+		s.Add("light-"+strconv.Itoa(i), 1)
+		total++
+	}
+
+	for i := range topK {
+		key := "heavy-" + strconv.Itoa(i)
+		for range 100 * (i + 1) {
+			s.Add(key, 1)
+			total++
+		}
+	}
+
+	require.Equal(t, total, s.Total())
+
+	for i := range lightNum {
+		est := s.Estimate("light-" + strconv.Itoa(i))
+		assert.GreaterOrEqual(t, est, uint64(1))
+		assert.LessOrEqual(t, est, uint64(10))
+	}
+
+	top := s.TopK()
+	require.Len(t, top, topK)
+
+	for i, kv := range top {
+		want := topK - 1 - i
+		assert.Equal(t, "heavy-"+strconv.Itoa(want), kv.Key)
+		assert.GreaterOrEqual(t, kv.Value, uint64(100*(want+1)))
+	}
+
+	s.Clear()
+	assert.Zero(t, s.Total())
+	assert.Zero(t, s.Estimate("heavy-0"))
+	assert.Empty(t, s.TopK())
+}
+
+func TestCountMinSketch_noTopK(t *testing.T) {
+	t.Parallel()
+
+	s := newTestCountMinSketch(t, 0)
+	assert.Equal(t, uint64(3), s.Add("a", 3))
+	assert.Equal(t, uint64(5), s.Add("a", 2))
+	assert.Empty(t, s.TopK())
+}
+
+func TestNewCountMinSketch_error(t *testing.T) {
+	t.Parallel()
+
+	_, err := container.NewCountMinSketch[string](nil)
+	assert.ErrorIs(t, err, errors.ErrNoValue)
+
+	_, err = container.NewCountMinSketch[string](&container.CountMinSketchConfig{})
+	require.Error(t, err)
+
+	assert.ErrorContains(t, err, "c.Width")
+	assert.ErrorContains(t, err, "c.Depth")
+}
+
+func BenchmarkCountMinSketch_Add(b *testing.B) {
+	s := newTestCountMinSketch(b, 10)
+
+	const n = 10_000
+
+	// Make the distribution of the keys skewed, so that the tracked keys are
+	// updated as well as replaced.
+	keys := make([]string, 0, n)
+	for i := range n {
+		keys = append(keys, strconv.Itoa(i*i%997))
+	}
+
+	var i int
+
+	b.ReportAllocs()
+	for b.Loop() {
+		s.Add(keys[i%n], 1)
+		i++
+	}
+
+	// Most recent results:
+	//	goos: linux
+	//	goarch: amd64
+	//	pkg: github.com/AdguardTeam/golibs/container
+	//	cpu: Intel(R) Xeon(R) Processor
+	//	BenchmarkCountMinSketch_Add 	15377946	        77.47 ns/op	       0 B/op	       0 allocs/op
+}
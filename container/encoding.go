@@ -0,0 +1,195 @@
+package container
+
+import (
+	"bytes"
+	"cmp"
+	"encoding"
+	"encoding/json"
+	"fmt"
+)
+
+// textSep is the separator of the values in the text encoding of sets.
+const textSep = ','
+
+// comparer is the interface for types with a method defining their natural
+// order, such as [netip.Addr].
+type comparer[T any] interface {
+	Compare(other T) (res int)
+}
+
+// compareFunc returns a function comparing the values of T in their natural
+// order and true, if T has a Compare method, like [netip.Addr], or if T is one
+// of the predeclared string or number types.  Otherwise, it returns nil and
+// false.
+func compareFunc[T any]() (f func(a, b T) (res int), ok bool) {
+	var zero T
+	switch any(zero).(type) {
+	case comparer[T]:
+		return compareByMethod[T], true
+	case string:
+		return compareOrdered[string, T], true
+	case float32:
+		return compareOrdered[float32, T], true
+	case float64:
+		return compareOrdered[float64, T], true
+	default:
+		return compareIntFunc[T]()
+	}
+}
+
+// compareIntFunc is like [compareFunc] but only handles the predeclared integer
+// types.
+func compareIntFunc[T any]() (f func(a, b T) (res int), ok bool) {
+	var zero T
+	switch any(zero).(type) {
+	case int:
+		return compareOrdered[int, T], true
+	case int8:
+		return compareOrdered[int8, T], true
+	case int16:
+		return compareOrdered[int16, T], true
+	case int32:
+		return compareOrdered[int32, T], true
+	case int64:
+		return compareOrdered[int64, T], true
+	default:
+		return compareUintFunc[T]()
+	}
+}
+
+// compareUintFunc is like [compareFunc] but only handles the predeclared
+// unsigned integer types.
+func compareUintFunc[T any]() (f func(a, b T) (res int), ok bool) {
+	var zero T
+	switch any(zero).(type) {
+	case uint:
+		return compareOrdered[uint, T], true
+	case uint8:
+		return compareOrdered[uint8, T], true
+	case uint16:
+		return compareOrdered[uint16, T], true
+	case uint32:
+		return compareOrdered[uint32, T], true
+	case uint64:
+		return compareOrdered[uint64, T], true
+	case uintptr:
+		return compareOrdered[uintptr, T], true
+	default:
+		return nil, false
+	}
+}
+
+// compareByMethod compares a and b using the Compare method of a.  T must
+// implement [comparer].
+func compareByMethod[T any](a, b T) (res int) {
+	return any(a).(comparer[T]).Compare(b)
+}
+
+// compareOrdered compares a and b as values of type O.  T must be O.
+func compareOrdered[O cmp.Ordered, T any](a, b T) (res int) {
+	return cmp.Compare(any(a).(O), any(b).(O))
+}
+
+// marshalJSONValue returns the JSON encoding of v.
+func marshalJSONValue[T any](v T) (b []byte, err error) {
+	return json.Marshal(v)
+}
+
+// marshalTextValue returns the text encoding of v.  T must either implement
+// [encoding.TextMarshaler] or be one of the predeclared string, number, or
+// boolean types.  The encoding must not contain commas.
+func marshalTextValue[T any](v T) (text []byte, err error) {
+	switch v := any(v).(type) {
+	case encoding.TextMarshaler:
+		text, err = v.MarshalText()
+	case string:
+		text = []byte(v)
+	case
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64, uintptr,
+		float32, float64,
+		bool:
+		// The JSON encodings of numbers and booleans are valid text encodings.
+		text, err = json.Marshal(v)
+	default:
+		err = fmt.Errorf("type %T: no text encoding", v)
+	}
+
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return nil, err
+	}
+
+	if bytes.IndexByte(text, textSep) >= 0 {
+		return nil, fmt.Errorf("value %q: contains separator %q", text, textSep)
+	}
+
+	return text, nil
+}
+
+// unmarshalTextValue decodes v from text.  T must either be a type whose
+// pointer implements [encoding.TextUnmarshaler] or be one of the predeclared
+// string, number, or boolean types.
+func unmarshalTextValue[T any](text []byte) (v T, err error) {
+	switch p := any(&v).(type) {
+	case encoding.TextUnmarshaler:
+		err = p.UnmarshalText(text)
+	case *string:
+		*p = string(text)
+	case
+		*int, *int8, *int16, *int32, *int64,
+		*uint, *uint8, *uint16, *uint32, *uint64, *uintptr,
+		*float32, *float64,
+		*bool:
+		err = json.Unmarshal(text, p)
+	default:
+		err = fmt.Errorf("type %T: no text encoding", v)
+	}
+
+	return v, err
+}
+
+// marshalTextValues returns the text encodings of values separated by commas.
+func marshalTextValues[T any](values []T) (text []byte, err error) {
+	for i, v := range values {
+		var b []byte
+		b, err = marshalTextValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("at index %d: %w", i, err)
+		}
+
+		if i > 0 {
+			text = append(text, textSep)
+		}
+
+		text = append(text, b...)
+	}
+
+	if text == nil {
+		return []byte{}, nil
+	}
+
+	return text, nil
+}
+
+// unmarshalTextValues decodes the values separated by commas from text.  If
+// text is empty, values is an empty non-nil slice.
+func unmarshalTextValues[T any](text []byte) (values []T, err error) {
+	if len(text) == 0 {
+		return []T{}, nil
+	}
+
+	parts := bytes.Split(text, []byte{textSep})
+	values = make([]T, 0, len(parts))
+	for i, p := range parts {
+		var v T
+		v, err = unmarshalTextValue[T](p)
+		if err != nil {
+			return nil, fmt.Errorf("at index %d: %w", i, err)
+		}
+
+		values = append(values, v)
+	}
+
+	return values, nil
+}
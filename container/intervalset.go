@@ -0,0 +1,294 @@
+package container
+
+import (
+	"slices"
+)
+
+// intervalOps is the interface for the operations on intervals of type I with
+// values of type T.  It defines whether the upper bounds of the intervals are
+// inclusive or exclusive, so that [RangeSet] and [ClosedRangeSet] can share the
+// implementation in [intervalSet].
+type intervalOps[T, I any] interface {
+	// bounds returns the lower and the upper bound of iv.
+	bounds(iv I) (lo, hi T)
+
+	// interval returns an interval with the given bounds.
+	interval(lo, hi T) (iv I)
+
+	// compare returns -1 if a is less than b, +1 if a is greater than b, and 0
+	// if they are equal.
+	compare(a, b T) (res int)
+
+	// isEmpty returns true if iv contains no values.
+	isEmpty(iv I) (ok bool)
+
+	// endsBefore returns true if all values of iv are less than v.
+	endsBefore(iv I, v T) (ok bool)
+
+	// isSeparate returns true if all values of a are less than the values of b
+	// and a and b can't be merged into one interval, that is, they neither
+	// overlap nor touch.
+	isSeparate(a, b I) (ok bool)
+
+	// head returns the values of a that are less than the first value of b.
+	// The lower bound of a must be less than the one of b.
+	head(a, b I) (iv I)
+
+	// tail returns the values of a that are greater than the last value of b.
+	// The upper bound of b must be less than the one of a.
+	tail(a, b I) (iv I)
+}
+
+// intervalSet is the shared implementation of range sets.  Its methods don't
+// check the receiver for nil, so the range set types must do that.
+type intervalSet[T, I any, O intervalOps[T, I]] struct {
+	ops O
+
+	// ivs are sorted, non-empty, and neither overlap nor touch.
+	ivs []I
+}
+
+// searchIntervals returns the index of the first interval in ivs for which
+// isBefore returns false.  isBefore must be true for some prefix of the
+// intervals and false for the rest.
+func searchIntervals[I, X any](ivs []I, target X, isBefore func(iv I, target X) (ok bool)) (i int) {
+	i, _ = slices.BinarySearchFunc(ivs, target, func(iv I, target X) (res int) {
+		if isBefore(iv, target) {
+			return -1
+		}
+
+		return 1
+	})
+
+	return i
+}
+
+// lo returns the lower bound of iv.
+func (s *intervalSet[T, I, O]) lo(iv I) (lo T) {
+	lo, _ = s.ops.bounds(iv)
+
+	return lo
+}
+
+// hi returns the upper bound of iv.
+func (s *intervalSet[T, I, O]) hi(iv I) (hi T) {
+	_, hi = s.ops.bounds(iv)
+
+	return hi
+}
+
+// add adds the values from iv to s, merging the intervals as necessary.
+func (s *intervalSet[T, I, O]) add(iv I) {
+	if s.ops.isEmpty(iv) {
+		return
+	}
+
+	// Find the intervals that overlap or touch iv.
+	i := searchIntervals(s.ivs, iv, s.ops.isSeparate)
+	j := searchIntervals(s.ivs, iv, func(other, iv I) (ok bool) {
+		return !s.ops.isSeparate(iv, other)
+	})
+	if i < j {
+		lo, hi := s.ops.bounds(iv)
+		lo = s.min(lo, s.lo(s.ivs[i]))
+		hi = s.max(hi, s.hi(s.ivs[j-1]))
+		iv = s.ops.interval(lo, hi)
+	}
+
+	s.ivs = slices.Replace(s.ivs, i, j, iv)
+}
+
+// delete deletes the values from iv from s, splitting the intervals as
+// necessary.
+func (s *intervalSet[T, I, O]) delete(iv I) {
+	if s.ops.isEmpty(iv) {
+		return
+	}
+
+	// Find the intervals that overlap iv.
+	lo, hi := s.ops.bounds(iv)
+	i := searchIntervals(s.ivs, lo, s.ops.endsBefore)
+	j := searchIntervals(s.ivs, iv, func(other, iv I) (ok bool) {
+		return !s.ops.endsBefore(iv, s.lo(other))
+	})
+	if i >= j {
+		return
+	}
+
+	rest := make([]I, 0, 2)
+	if first := s.ivs[i]; s.ops.compare(s.lo(first), lo) < 0 {
+		rest = append(rest, s.ops.head(first, iv))
+	}
+
+	if last := s.ivs[j-1]; s.ops.compare(s.hi(last), hi) > 0 {
+		rest = append(rest, s.ops.tail(last, iv))
+	}
+
+	s.ivs = slices.Replace(s.ivs, i, j, rest...)
+}
+
+// find returns the interval of s that contains v.
+func (s *intervalSet[T, I, O]) find(v T) (iv I, ok bool) {
+	i, _ := slices.BinarySearchFunc(s.ivs, v, func(iv I, v T) (res int) {
+		if s.ops.endsBefore(iv, v) {
+			return -1
+		}
+
+		return 1
+	})
+	if i == len(s.ivs) || s.ops.compare(s.lo(s.ivs[i]), v) > 0 {
+		return iv, false
+	}
+
+	return s.ivs[i], true
+}
+
+// hasInterval returns true if all values from iv are in s.
+func (s *intervalSet[T, I, O]) hasInterval(iv I) (ok bool) {
+	if s.ops.isEmpty(iv) {
+		return true
+	}
+
+	lo, hi := s.ops.bounds(iv)
+	other, ok := s.find(lo)
+
+	return ok && s.ops.compare(s.hi(other), hi) >= 0
+}
+
+// overlaps returns true if s has at least one value from iv.
+func (s *intervalSet[T, I, O]) overlaps(iv I) (ok bool) {
+	if s.ops.isEmpty(iv) {
+		return false
+	}
+
+	i := searchIntervals(s.ivs, s.lo(iv), s.ops.endsBefore)
+
+	return i < len(s.ivs) && !s.ops.endsBefore(iv, s.lo(s.ivs[i]))
+}
+
+// clear clears s in a way that retains the internal storage.
+func (s *intervalSet[T, I, O]) clear() {
+	clear(s.ivs)
+	s.ivs = s.ivs[:0]
+}
+
+// clone returns a deep clone of s.
+func (s *intervalSet[T, I, O]) clone() (clone intervalSet[T, I, O]) {
+	return intervalSet[T, I, O]{
+		ops: s.ops,
+		ivs: slices.Clone(s.ivs),
+	}
+}
+
+// equal returns true if s and other contain the same intervals.
+func (s *intervalSet[T, I, O]) equal(other *intervalSet[T, I, O]) (ok bool) {
+	return slices.EqualFunc(s.ivs, other.ivs, func(a, b I) (eq bool) {
+		aLo, aHi := s.ops.bounds(a)
+		bLo, bHi := s.ops.bounds(b)
+
+		return s.ops.compare(aLo, bLo) == 0 && s.ops.compare(aHi, bHi) == 0
+	})
+}
+
+// union fills s with the union of a and b.  isAliased must be true if a or b
+// share storage with s.
+func (s *intervalSet[T, I, O]) union(a, b []I, isAliased bool) {
+	dst := s.dst(isAliased, len(a)+len(b))
+	for len(a) > 0 || len(b) > 0 {
+		var next I
+		if len(b) == 0 || (len(a) > 0 && s.ops.compare(s.lo(a[0]), s.lo(b[0])) <= 0) {
+			next, a = a[0], a[1:]
+		} else {
+			next, b = b[0], b[1:]
+		}
+
+		dst = s.appendMerged(dst, next)
+	}
+
+	s.ivs = dst
+}
+
+// appendMerged appends iv to dst, merging it with the last interval of dst if
+// they overlap or touch, and returns the result.  iv must not start before the
+// last interval of dst.
+func (s *intervalSet[T, I, O]) appendMerged(dst []I, iv I) (res []I) {
+	n := len(dst)
+	if n == 0 || s.ops.isSeparate(dst[n-1], iv) {
+		return append(dst, iv)
+	}
+
+	lo, hi := s.ops.bounds(dst[n-1])
+	dst[n-1] = s.ops.interval(lo, s.max(hi, s.hi(iv)))
+
+	return dst
+}
+
+// difference fills s with the intervals of a without the values of b.
+// isAliased must be true if a or b share storage with s.
+func (s *intervalSet[T, I, O]) difference(a, b []I, isAliased bool) {
+	dst := s.dst(isAliased, len(a)+len(b))
+	for _, iv := range a {
+		// Skip the intervals of b that end before iv.  The next ones may
+		// still overlap the following intervals of a, so keep them.
+		for len(b) > 0 && s.ops.endsBefore(b[0], s.lo(iv)) {
+			b = b[1:]
+		}
+
+		dst = s.appendSubtracted(dst, iv, b)
+	}
+
+	s.ivs = dst
+}
+
+// appendSubtracted appends the parts of iv that don't overlap the intervals of
+// b to dst and returns the result.  b must be sorted and disjoint, and its first
+// interval must not end before iv.
+func (s *intervalSet[T, I, O]) appendSubtracted(dst []I, iv I, b []I) (res []I) {
+	for _, other := range b {
+		if s.ops.endsBefore(iv, s.lo(other)) {
+			break
+		}
+
+		if s.ops.compare(s.lo(other), s.lo(iv)) > 0 {
+			dst = append(dst, s.ops.head(iv, other))
+		}
+
+		if s.ops.compare(s.hi(other), s.hi(iv)) >= 0 {
+			return dst
+		}
+
+		iv = s.ops.tail(iv, other)
+	}
+
+	return append(dst, iv)
+}
+
+// dst returns the slice into which the result of an operation should be
+// written.  isAliased must be true if the operands share storage with s.
+func (s *intervalSet[T, I, O]) dst(isAliased bool, n int) (dst []I) {
+	if isAliased {
+		return make([]I, 0, n)
+	}
+
+	s.clear()
+
+	return s.ivs
+}
+
+// min returns the lesser of a and b.
+func (s *intervalSet[T, I, O]) min(a, b T) (res T) {
+	if s.ops.compare(a, b) <= 0 {
+		return a
+	}
+
+	return b
+}
+
+// max returns the greater of a and b.
+func (s *intervalSet[T, I, O]) max(a, b T) (res T) {
+	if s.ops.compare(a, b) >= 0 {
+		return a
+	}
+
+	return b
+}
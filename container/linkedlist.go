@@ -0,0 +1,300 @@
+package container
+
+import "iter"
+
+// ListElement is an element of a [LinkedList].  A ListElement may be embedded
+// into another structure and added to a list with [LinkedList.PushBackElement]
+// or [LinkedList.PushFrontElement] to avoid an additional allocation.
+type ListElement[T any] struct {
+	next *ListElement[T]
+	prev *ListElement[T]
+
+	// list is the list to which the element belongs.  It is nil if the element
+	// isn't in a list.
+	list *LinkedList[T]
+
+	// Value is the value stored in the element.
+	Value T
+}
+
+// Next returns the next element of the list or nil.  It returns nil if e is
+// the last element or if e is not in a list.
+func (e *ListElement[T]) Next() (next *ListElement[T]) {
+	if e.list == nil || e.next == &e.list.root {
+		return nil
+	}
+
+	return e.next
+}
+
+// Prev returns the previous element of the list or nil.  It returns nil if e
+// is the first element or if e is not in a list.
+func (e *ListElement[T]) Prev() (prev *ListElement[T]) {
+	if e.list == nil || e.prev == &e.list.root {
+		return nil
+	}
+
+	return e.prev
+}
+
+// LinkedList is a generic doubly linked list.  The zero value of LinkedList is
+// an empty list ready to use.  A LinkedList must not be copied after first use.
+//
+// The methods that accept elements do nothing if the element is nil or doesn't
+// belong to the list.
+type LinkedList[T any] struct {
+	// root is the sentinel element.  root.next is the first element, and
+	// root.prev is the last one.
+	root ListElement[T]
+	len  int
+}
+
+// NewLinkedList returns a new list containing values in the same order.
+func NewLinkedList[T any](values ...T) (l *LinkedList[T]) {
+	l = &LinkedList[T]{}
+	for _, v := range values {
+		l.PushBack(v)
+	}
+
+	return l
+}
+
+// lazyInit initializes the sentinel element of a zero list.
+func (l *LinkedList[T]) lazyInit() {
+	if l.root.next == nil {
+		l.root.next = &l.root
+		l.root.prev = &l.root
+	}
+}
+
+// Len returns the number of elements in l.  A nil list has a length of zero.
+func (l *LinkedList[T]) Len() (n int) {
+	if l == nil {
+		return 0
+	}
+
+	return l.len
+}
+
+// Front returns the first element of l or nil if l is empty.
+func (l *LinkedList[T]) Front() (e *ListElement[T]) {
+	if l.Len() == 0 {
+		return nil
+	}
+
+	return l.root.next
+}
+
+// Back returns the last element of l or nil if l is empty.
+func (l *LinkedList[T]) Back() (e *ListElement[T]) {
+	if l.Len() == 0 {
+		return nil
+	}
+
+	return l.root.prev
+}
+
+// has returns true if e is an element of l.
+func (l *LinkedList[T]) has(e *ListElement[T]) (ok bool) {
+	return l != nil && e != nil && e.list == l
+}
+
+// insert inserts e after at and returns e.
+func (l *LinkedList[T]) insert(e, at *ListElement[T]) (res *ListElement[T]) {
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+	e.list = l
+	l.len++
+
+	return e
+}
+
+// unlink removes e from its list without resetting its list.
+func (l *LinkedList[T]) unlink(e *ListElement[T]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	l.len--
+}
+
+// move moves e to after at.
+func (l *LinkedList[T]) move(e, at *ListElement[T]) {
+	if e == at {
+		return
+	}
+
+	e.prev.next = e.next
+	e.next.prev = e.prev
+
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+}
+
+// PushFront inserts a new element with v at the front of l and returns it.
+func (l *LinkedList[T]) PushFront(v T) (e *ListElement[T]) {
+	l.lazyInit()
+
+	return l.insert(&ListElement[T]{Value: v}, &l.root)
+}
+
+// PushBack inserts a new element with v at the back of l and returns it.
+func (l *LinkedList[T]) PushBack(v T) (e *ListElement[T]) {
+	l.lazyInit()
+
+	return l.insert(&ListElement[T]{Value: v}, l.root.prev)
+}
+
+// PushFrontElement inserts e at the front of l.  e must not be nil and must not
+// be in any list.
+func (l *LinkedList[T]) PushFrontElement(e *ListElement[T]) {
+	l.lazyInit()
+	l.insert(e, &l.root)
+}
+
+// PushBackElement inserts e at the back of l.  e must not be nil and must not
+// be in any list.
+func (l *LinkedList[T]) PushBackElement(e *ListElement[T]) {
+	l.lazyInit()
+	l.insert(e, l.root.prev)
+}
+
+// InsertBefore inserts a new element with v right before mark and returns it.
+// If mark is not an element of l, l is not modified and e is nil.
+func (l *LinkedList[T]) InsertBefore(v T, mark *ListElement[T]) (e *ListElement[T]) {
+	if !l.has(mark) {
+		return nil
+	}
+
+	return l.insert(&ListElement[T]{Value: v}, mark.prev)
+}
+
+// InsertAfter inserts a new element with v right after mark and returns it.  If
+// mark is not an element of l, l is not modified and e is nil.
+func (l *LinkedList[T]) InsertAfter(v T, mark *ListElement[T]) (e *ListElement[T]) {
+	if !l.has(mark) {
+		return nil
+	}
+
+	return l.insert(&ListElement[T]{Value: v}, mark)
+}
+
+// Remove removes e from l if e is an element of l.  It returns the value of e
+// or the zero value if e is nil.  e may be added to a list again afterwards.
+func (l *LinkedList[T]) Remove(e *ListElement[T]) (v T) {
+	if e == nil {
+		return v
+	}
+
+	if l.has(e) {
+		l.unlink(e)
+		e.next, e.prev, e.list = nil, nil, nil
+	}
+
+	return e.Value
+}
+
+// MoveToFront moves e to the front of l.  If e is not an element of l, l is not
+// modified.
+func (l *LinkedList[T]) MoveToFront(e *ListElement[T]) {
+	if l.has(e) && l.root.next != e {
+		l.move(e, &l.root)
+	}
+}
+
+// MoveToBack moves e to the back of l.  If e is not an element of l, l is not
+// modified.
+func (l *LinkedList[T]) MoveToBack(e *ListElement[T]) {
+	if l.has(e) && l.root.prev != e {
+		l.move(e, l.root.prev)
+	}
+}
+
+// MoveBefore moves e right before mark.  If e or mark is not an element of l,
+// or e is mark, l is not modified.
+func (l *LinkedList[T]) MoveBefore(e, mark *ListElement[T]) {
+	if l.has(e) && l.has(mark) && e != mark {
+		l.move(e, mark.prev)
+	}
+}
+
+// MoveAfter moves e right after mark.  If e or mark is not an element of l, or
+// e is mark, l is not modified.
+func (l *LinkedList[T]) MoveAfter(e, mark *ListElement[T]) {
+	if l.has(e) && l.has(mark) && e != mark {
+		l.move(e, mark)
+	}
+}
+
+// Clear removes all elements from l.  Calling Clear on a nil list has no
+// effect.
+func (l *LinkedList[T]) Clear() {
+	if l.Len() == 0 {
+		return
+	}
+
+	for e := l.root.next; e != &l.root; {
+		next := e.next
+		e.next, e.prev, e.list = nil, nil, nil
+		e = next
+	}
+
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	l.len = 0
+}
+
+// Range calls f with each value of l from the front to the back until f
+// returns false.  f may remove the current element from l.  Calling Range on a
+// nil list has no effect.
+func (l *LinkedList[T]) Range(f func(v T) (cont bool)) {
+	for e := l.Front(); e != nil; {
+		next := e.Next()
+		if !f(e.Value) {
+			return
+		}
+
+		e = next
+	}
+}
+
+// ReverseRange is like [LinkedList.Range] but from the back to the front.
+func (l *LinkedList[T]) ReverseRange(f func(v T) (cont bool)) {
+	for e := l.Back(); e != nil; {
+		prev := e.Prev()
+		if !f(e.Value) {
+			return
+		}
+
+		e = prev
+	}
+}
+
+// All returns an iterator over the values of l from the front to the back.  The
+// current element may be removed from l during the iteration.
+func (l *LinkedList[T]) All() (seq iter.Seq[T]) {
+	return l.Range
+}
+
+// Backward returns an iterator over the values of l from the back to the front.
+// The current element may be removed from l during the iteration.
+func (l *LinkedList[T]) Backward() (seq iter.Seq[T]) {
+	return l.ReverseRange
+}
+
+// Values returns all values of l from the front to the back.  Values returns
+// nil if l is empty.
+func (l *LinkedList[T]) Values() (values []T) {
+	if l.Len() == 0 {
+		return nil
+	}
+
+	values = make([]T, 0, l.len)
+	for e := l.root.next; e != &l.root; e = e.next {
+		values = append(values, e.Value)
+	}
+
+	return values
+}
@@ -0,0 +1,70 @@
+package container_test
+
+import (
+	"fmt"
+
+	"github.com/AdguardTeam/golibs/container"
+)
+
+func ExampleLinkedList() {
+	l := container.NewLinkedList(1, 2, 3)
+	fmt.Println(l.Values(), l.Len())
+
+	l.PushFront(0)
+	e := l.PushBack(4)
+	fmt.Println(l.Values(), l.Len())
+
+	l.MoveToFront(e)
+	fmt.Println(l.Values())
+
+	l.MoveBefore(e, l.Back())
+	fmt.Println(l.Values())
+
+	fmt.Println(l.Remove(l.Front()), l.Values())
+
+	var reversed []int
+	for v := range l.ReverseRange {
+		reversed = append(reversed, v)
+	}
+
+	fmt.Println(reversed)
+
+	l.Clear()
+	fmt.Println(l.Values(), l.Len())
+
+	// Output:
+	// [1 2 3] 3
+	// [0 1 2 3 4] 5
+	// [4 0 1 2 3]
+	// [0 1 2 4 3]
+	// 0 [1 2 4 3]
+	// [3 4 2 1]
+	// [] 0
+}
+
+func ExampleLinkedList_PushBackElement() {
+	// item embeds the list element to avoid an additional allocation.
+	type item struct {
+		elem container.ListElement[*item]
+		name string
+	}
+
+	l := &container.LinkedList[*item]{}
+	for _, name := range []string{"a", "b", "c"} {
+		it := &item{name: name}
+		it.elem.Value = it
+		l.PushBackElement(&it.elem)
+	}
+
+	first := l.Front().Value
+	l.MoveToBack(&first.elem)
+
+	for e := l.Front(); e != nil; e = e.Next() {
+		fmt.Println(e.Value.name)
+	}
+
+	// Output:
+	// b
+	// c
+	// a
+}
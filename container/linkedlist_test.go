@@ -0,0 +1,163 @@
+package container_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkedList_zero(t *testing.T) {
+	t.Parallel()
+
+	var nilList *container.LinkedList[int]
+	assert.Zero(t, nilList.Len())
+	assert.Nil(t, nilList.Front())
+	assert.Nil(t, nilList.Back())
+	assert.Nil(t, nilList.Values())
+	assert.NotPanics(t, nilList.Clear)
+
+	l := &container.LinkedList[int]{}
+	assert.Zero(t, l.Len())
+	assert.Nil(t, l.Front())
+
+	e := l.PushBack(1)
+	assert.Equal(t, e, l.Front())
+	assert.Equal(t, e, l.Back())
+	assert.Nil(t, e.Next())
+	assert.Nil(t, e.Prev())
+}
+
+func TestLinkedList_foreignElement(t *testing.T) {
+	t.Parallel()
+
+	l := container.NewLinkedList(1, 2)
+	other := container.NewLinkedList(3)
+	foreign := other.Front()
+
+	l.MoveToFront(foreign)
+	l.MoveToBack(foreign)
+	l.MoveBefore(foreign, l.Front())
+	l.MoveAfter(l.Front(), foreign)
+	assert.Nil(t, l.InsertBefore(0, foreign))
+	assert.Nil(t, l.InsertAfter(0, foreign))
+	assert.Equal(t, 3, l.Remove(foreign))
+
+	assert.Equal(t, []int{1, 2}, l.Values())
+	assert.Equal(t, []int{3}, other.Values())
+
+	orphan := &container.ListElement[int]{Value: 4}
+	l.MoveToFront(orphan)
+	l.MoveToBack(orphan)
+	assert.Nil(t, l.InsertAfter(0, orphan))
+	assert.Equal(t, 4, l.Remove(orphan))
+
+	assert.NotPanics(t, func() {
+		l.MoveToFront(nil)
+		l.MoveToBack(nil)
+		l.MoveBefore(nil, l.Front())
+		l.MoveAfter(l.Front(), nil)
+		assert.Nil(t, l.InsertBefore(0, nil))
+		assert.Nil(t, l.InsertAfter(0, nil))
+		assert.Zero(t, l.Remove(nil))
+
+		var nilList *container.LinkedList[int]
+		nilList.MoveToFront(orphan)
+		nilList.MoveToBack(orphan)
+	})
+
+	assert.Equal(t, []int{1, 2}, l.Values())
+}
+
+func TestLinkedList_All(t *testing.T) {
+	t.Parallel()
+
+	l := container.NewLinkedList(1, 2, 3)
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(l.All()))
+	assert.Equal(t, []int{3, 2, 1}, slices.Collect(l.Backward()))
+
+	var got []int
+	for v := range l.All() {
+		if v == 2 {
+			break
+		}
+
+		got = append(got, v)
+	}
+
+	assert.Equal(t, []int{1}, got)
+
+	var nilList *container.LinkedList[int]
+	assert.Empty(t, slices.Collect(nilList.All()))
+	assert.Empty(t, slices.Collect(nilList.Backward()))
+}
+
+func TestLinkedList_Insert(t *testing.T) {
+	t.Parallel()
+
+	l := container.NewLinkedList(1, 3)
+
+	e := l.InsertAfter(2, l.Front())
+	require.NotNil(t, e)
+
+	assert.Equal(t, []int{1, 2, 3}, l.Values())
+
+	l.InsertBefore(0, l.Front())
+	assert.Equal(t, []int{0, 1, 2, 3}, l.Values())
+
+	l.MoveAfter(l.Front(), l.Back())
+	assert.Equal(t, []int{1, 2, 3, 0}, l.Values())
+
+	l.MoveBefore(l.Back(), e)
+	assert.Equal(t, []int{1, 0, 2, 3}, l.Values())
+	assert.Equal(t, 4, l.Len())
+}
+
+func TestLinkedList_Range_remove(t *testing.T) {
+	t.Parallel()
+
+	l := container.NewLinkedList[int]()
+	elems := []*container.ListElement[int]{}
+	for i := range 5 {
+		elems = append(elems, l.PushBack(i))
+	}
+
+	for v := range l.Range {
+		if v%2 == 0 {
+			l.Remove(elems[v])
+		}
+	}
+
+	assert.Equal(t, []int{1, 3}, l.Values())
+
+	var got []int
+	for v := range l.ReverseRange {
+		got = append(got, v)
+
+		break
+	}
+
+	assert.Equal(t, []int{3}, got)
+}
+
+func TestLinkedList_Clear(t *testing.T) {
+	t.Parallel()
+
+	l := container.NewLinkedList(1, 2)
+	e := l.Front()
+
+	l.Clear()
+	assert.Zero(t, l.Len())
+	assert.Nil(t, e.Next())
+
+	// Removing an element that has been cleared must not affect the list.
+	l.PushBack(3)
+	l.Remove(e)
+	assert.Equal(t, []int{3}, l.Values())
+
+	// The element may be added again.
+	l.PushFrontElement(e)
+	assert.Equal(t, []int{1, 3}, l.Values())
+}
@@ -2,9 +2,12 @@ package container
 
 import (
 	"cmp"
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"maps"
 	"slices"
+	"strings"
 
 	"github.com/AdguardTeam/golibs/errors"
 )
@@ -36,6 +39,13 @@ func (set *MapSet[T]) Add(v T) {
 	set.m[v] = unit{}
 }
 
+// AddAll adds values to set.  set must not be nil.
+func (set *MapSet[T]) AddAll(values ...T) {
+	for _, v := range values {
+		set.m[v] = unit{}
+	}
+}
+
 // Clear clears set in a way that retains the internal storage for later reuse
 // to reduce allocations.  Calling Clear on a nil set has no effect, just like a
 // clear on a nil map doesn't.
@@ -64,6 +74,18 @@ func (set *MapSet[T]) Delete(v T) {
 	}
 }
 
+// DeleteAll deletes values from set.  Calling DeleteAll on a nil set has no
+// effect.
+func (set *MapSet[T]) DeleteAll(values ...T) {
+	if set == nil {
+		return
+	}
+
+	for _, v := range values {
+		delete(set.m, v)
+	}
+}
+
 // Equal returns true if set is equal to other.  set and other may be nil; Equal
 // returns true if both are nil, but a nil *MapSet is not equal to a non-nil
 // empty one.
@@ -241,3 +263,292 @@ func (set *MapSet[T]) Intersects(other *MapSet[T]) (ok bool) {
 
 	return false
 }
+
+// Difference fills set with values that belong to a but not to b.  set must
+// not be nil.  Difference returns an empty set if a is nil.  If neither a nor b
+// are equal to set, then the function will rewrite the contents of set.  If b
+// is equal to set, the function allocates new storage for set.
+func (set *MapSet[T]) Difference(a, b *MapSet[T]) (res *MapSet[T]) {
+	if set == nil {
+		panic(fmt.Errorf("set: %v", errors.ErrNoValue))
+	}
+
+	switch {
+	case a == nil:
+		set.Clear()
+	case set == a:
+		for v := range b.Range {
+			delete(set.m, v)
+		}
+	case set == b:
+		// The values of b are needed while the result is calculated, so use
+		// new storage.
+		other := &MapSet[T]{m: b.m}
+		set.m = make(map[T]unit, len(a.m))
+		set.addDifference(a, other)
+	default:
+		set.Clear()
+		set.addDifference(a, b)
+	}
+
+	return set
+}
+
+// addDifference adds the values of a that are not in b to set.  set and a must
+// not be nil.
+func (set *MapSet[T]) addDifference(a, b *MapSet[T]) {
+	for v := range a.m {
+		if !b.Has(v) {
+			set.m[v] = unit{}
+		}
+	}
+}
+
+// SymmetricDifference fills set with values that belong to either a or b but
+// not to both of them.  set must not be nil.  SymmetricDifference returns an
+// empty set if both a and b are nil.  If neither a nor b are equal to set, then
+// the function will rewrite the contents of set.
+func (set *MapSet[T]) SymmetricDifference(a, b *MapSet[T]) (res *MapSet[T]) {
+	if set == nil {
+		panic(fmt.Errorf("set: %v", errors.ErrNoValue))
+	}
+
+	switch {
+	case a == b:
+		set.Clear()
+	case set == a:
+		set.toggle(b)
+	case set == b:
+		set.toggle(a)
+	default:
+		set.Clear()
+		set.toggle(a)
+		set.toggle(b)
+	}
+
+	return set
+}
+
+// toggle adds the values of other that are not in set to set and deletes the
+// ones that are.  set must not be nil and must not be equal to other.
+func (set *MapSet[T]) toggle(other *MapSet[T]) {
+	for v := range other.Range {
+		if _, ok := set.m[v]; ok {
+			delete(set.m, v)
+		} else {
+			set.m[v] = unit{}
+		}
+	}
+}
+
+// IsSubset returns true if every value of set is also in other.  A nil set is
+// considered empty, and an empty set is a subset of any set.
+func (set *MapSet[T]) IsSubset(other *MapSet[T]) (ok bool) {
+	if set.Len() > other.Len() {
+		return false
+	}
+
+	for v := range set.Range {
+		if !other.Has(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSuperset returns true if every value of other is also in set.  A nil set is
+// considered empty, and any set is a superset of an empty set.
+func (set *MapSet[T]) IsSuperset(other *MapSet[T]) (ok bool) {
+	return other.IsSubset(set)
+}
+
+// type check
+var _ json.Marshaler = MapSet[int]{}
+
+// MarshalJSON implements the [json.Marshaler] interface for MapSet.  It has a
+// value receiver, so that fields of type MapSet are encoded as well as the ones
+// of type *MapSet.  set is encoded as a JSON array.  Since the order of the
+// values in a map is undefined, the values are sorted to make the result
+// reproducible: in their natural order, if T has a Compare method, like
+// [netip.Addr], or if T is one of the predeclared string or number types, and
+// by their JSON encodings otherwise.  An empty set is encoded as an empty
+// array.
+func (set MapSet[T]) MarshalJSON() (b []byte, err error) {
+	sorted, err := set.sorted(marshalJSONValue[T])
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return nil, err
+	}
+
+	b = append(b, '[')
+	for i, kv := range sorted {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		b = append(b, kv.Key...)
+	}
+
+	return append(b, ']'), nil
+}
+
+// type check
+var _ json.Unmarshaler = (*MapSet[int])(nil)
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface for *MapSet.  b
+// must be a JSON array or null.  The values from b replace the contents of set,
+// and null leaves set unchanged.
+func (set *MapSet[T]) UnmarshalJSON(b []byte) (err error) {
+	var values []T
+	err = json.Unmarshal(b, &values)
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return err
+	}
+
+	set.replace(values)
+
+	return nil
+}
+
+// type check
+var _ encoding.TextMarshaler = MapSet[int]{}
+
+// MarshalText implements the [encoding.TextMarshaler] interface for MapSet.
+// set is encoded as the text encodings of its values separated by commas, in
+// the same order as in [MapSet.MarshalJSON], except that the values without a
+// natural order are sorted by their text encodings.  T must either implement
+// [encoding.TextMarshaler] or be one of the predeclared string, number, or
+// boolean types, and the encodings of the values must not contain commas.
+func (set MapSet[T]) MarshalText() (text []byte, err error) {
+	sorted, err := set.sorted(marshalTextValue[T])
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return nil, err
+	}
+
+	text = []byte{}
+	for i, kv := range sorted {
+		if i > 0 {
+			text = append(text, textSep)
+		}
+
+		text = append(text, kv.Key...)
+	}
+
+	return text, nil
+}
+
+// type check
+var _ encoding.TextUnmarshaler = (*MapSet[int])(nil)
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface for
+// *MapSet.  text must be in the format described in [MapSet.MarshalText].  The
+// values from text replace the contents of set.  An empty text is decoded as an
+// empty set.
+func (set *MapSet[T]) UnmarshalText(text []byte) (err error) {
+	values, err := unmarshalTextValues[T](text)
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return err
+	}
+
+	set.replace(values)
+
+	return nil
+}
+
+// MarshalYAML implements the Marshaler interface of the YAML libraries for
+// MapSet.  set is encoded as a sequence in the same order as in
+// [MapSet.MarshalJSON].
+func (set MapSet[T]) MarshalYAML() (v any, err error) {
+	if valCmp, ok := compareFunc[T](); ok {
+		values := set.Values()
+		if values == nil {
+			values = []T{}
+		}
+
+		slices.SortFunc(values, valCmp)
+
+		return values, nil
+	}
+
+	sorted, err := set.sorted(marshalJSONValue[T])
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return nil, err
+	}
+
+	values := make([]T, 0, len(sorted))
+	for _, kv := range sorted {
+		values = append(values, kv.Value)
+	}
+
+	return values, nil
+}
+
+// UnmarshalYAML implements the function-based Unmarshaler interface of the YAML
+// libraries for *MapSet.  It works the same way as [MapSet.UnmarshalJSON].
+func (set *MapSet[T]) UnmarshalYAML(unmarshal func(v any) (err error)) (err error) {
+	var values []T
+	err = unmarshal(&values)
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return err
+	}
+
+	set.replace(values)
+
+	return nil
+}
+
+// replace replaces the contents of set with values unless values is nil.  set
+// must not be nil.
+func (set *MapSet[T]) replace(values []T) {
+	if values == nil {
+		return
+	}
+
+	if set.m == nil {
+		set.m = make(map[T]unit, len(values))
+	} else {
+		clear(set.m)
+	}
+
+	set.AddAll(values...)
+}
+
+// sorted returns the values of set along with their encodings produced by
+// encode, sorted in the natural order of the values, if there is one, see
+// [compareFunc], and by the encodings otherwise.  sorted is never nil.
+func (set *MapSet[T]) sorted(
+	encode func(v T) (b []byte, err error),
+) (sorted []KeyValue[string, T], err error) {
+	sorted = make([]KeyValue[string, T], 0, set.Len())
+	for v := range set.Range {
+		var b []byte
+		b, err = encode(v)
+		if err != nil {
+			return nil, fmt.Errorf("encoding value: %w", err)
+		}
+
+		sorted = append(sorted, KeyValue[string, T]{
+			Key:   string(b),
+			Value: v,
+		})
+	}
+
+	valCmp, ok := compareFunc[T]()
+	if ok {
+		slices.SortFunc(sorted, func(a, b KeyValue[string, T]) (res int) {
+			return valCmp(a.Value, b.Value)
+		})
+	} else {
+		slices.SortFunc(sorted, func(a, b KeyValue[string, T]) (res int) {
+			return strings.Compare(a.Key, b.Key)
+		})
+	}
+
+	return sorted, nil
+}
@@ -1,6 +1,7 @@
 package container_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"slices"
 
@@ -260,3 +261,118 @@ func ExampleMapSet_nil() {
 	// panic after intersection: true
 	// panic after intersects: false
 }
+
+func ExampleMapSet_Difference() {
+	a := container.NewMapSet(1, 6, 10)
+	b := container.NewMapSet(3, 6, 12)
+	set := container.NewMapSet[int]()
+
+	str := container.MapSetToString[int]
+	fmt.Printf("a = %s, b = %s\n", str(a), str(b))
+	fmt.Printf("set = a \\ b:     %s\n", str(set.Difference(a, b)))
+	fmt.Printf("set = nil \\ nil: %s\n", str(set.Difference(nil, nil)))
+	fmt.Printf("set = nil \\ b:   %s\n", str(set.Difference(nil, b)))
+	fmt.Printf("set = a \\ nil:   %s\n", str(set.Difference(a, nil)))
+	fmt.Printf("a = a \\ b:       %s\n", str(a.Difference(a, b)))
+
+	a = container.NewMapSet(1, 6, 10)
+	fmt.Printf("b = a \\ b:       %s\n", str(b.Difference(a, b)))
+
+	// Output:
+	// a = [1 6 10], b = [3 6 12]
+	// set = a \ b:     [1 10]
+	// set = nil \ nil: []
+	// set = nil \ b:   []
+	// set = a \ nil:   [1 6 10]
+	// a = a \ b:       [1 10]
+	// b = a \ b:       [1 10]
+}
+
+func ExampleMapSet_SymmetricDifference() {
+	a := container.NewMapSet(1, 6, 10)
+	b := container.NewMapSet(3, 6, 12)
+	set := container.NewMapSet[int]()
+
+	str := container.MapSetToString[int]
+	fmt.Printf("a = %s, b = %s\n", str(a), str(b))
+	fmt.Printf("set = a △ b:     %s\n", str(set.SymmetricDifference(a, b)))
+	fmt.Printf("set = nil △ nil: %s\n", str(set.SymmetricDifference(nil, nil)))
+	fmt.Printf("set = nil △ b:   %s\n", str(set.SymmetricDifference(nil, b)))
+	fmt.Printf("set = a △ nil:   %s\n", str(set.SymmetricDifference(a, nil)))
+	fmt.Printf("a = a △ b:       %s\n", str(a.SymmetricDifference(a, b)))
+
+	a = container.NewMapSet(1, 6, 10)
+	fmt.Printf("b = a △ b:       %s\n", str(b.SymmetricDifference(a, b)))
+
+	// Output:
+	// a = [1 6 10], b = [3 6 12]
+	// set = a △ b:     [1 3 10 12]
+	// set = nil △ nil: []
+	// set = nil △ b:   [3 6 12]
+	// set = a △ nil:   [1 6 10]
+	// a = a △ b:       [1 3 10 12]
+	// b = a △ b:       [1 3 10 12]
+}
+
+func ExampleMapSet_IsSubset() {
+	a := container.NewMapSet(1, 6)
+	b := container.NewMapSet(1, 6, 12)
+	var nilSet *container.MapSet[int]
+
+	str := container.MapSetToString[int]
+	fmt.Printf("a = %s, b = %s\n", str(a), str(b))
+	fmt.Printf("a ⊆ b:     %t\n", a.IsSubset(b))
+	fmt.Printf("b ⊆ a:     %t\n", b.IsSubset(a))
+	fmt.Printf("b ⊇ a:     %t\n", b.IsSuperset(a))
+	fmt.Printf("nil ⊆ a:   %t\n", nilSet.IsSubset(a))
+	fmt.Printf("a ⊆ nil:   %t\n", a.IsSubset(nilSet))
+	fmt.Printf("nil ⊆ nil: %t\n", nilSet.IsSubset(nilSet))
+
+	// Output:
+	// a = [1 6], b = [1 6 12]
+	// a ⊆ b:     true
+	// b ⊆ a:     false
+	// b ⊇ a:     true
+	// nil ⊆ a:   true
+	// a ⊆ nil:   false
+	// nil ⊆ nil: true
+}
+
+func ExampleMapSet_AddAll() {
+	set := container.NewMapSet(1, 6)
+
+	set.AddAll(10, 3, 6)
+	fmt.Println(container.MapSetToString(set))
+
+	set.DeleteAll(1, 10, 12)
+	fmt.Println(container.MapSetToString(set))
+
+	// Output:
+	// [1 3 6 10]
+	// [3 6]
+}
+
+func ExampleMapSet_MarshalJSON() {
+	type config struct {
+		Hosts *container.MapSet[string] `json:"hosts"`
+	}
+
+	conf := &config{}
+	err := json.Unmarshal([]byte(`{"hosts":["b.example","a.example","b.example"]}`), conf)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(conf.Hosts.Has("a.example"), conf.Hosts.Len())
+
+	b, err := json.Marshal(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(string(b))
+
+	// Output:
+	// true 2
+	// {"hosts":["a.example","b.example"]}
+}
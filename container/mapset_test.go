@@ -1,11 +1,16 @@
 package container_test
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
+	"net/netip"
 	"testing"
 	"time"
 
 	"github.com/AdguardTeam/golibs/container"
+	"github.com/AdguardTeam/golibs/testutil"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -221,3 +226,171 @@ func BenchmarkMapSet_Has(b *testing.B) {
 	//	BenchmarkMapSet_Has/100000_strings
 	//	BenchmarkMapSet_Has/100000_strings-16     	88061529	        13.01 ns/op	       0 B/op	       0 allocs/op
 }
+
+func TestMapSet_encoding(t *testing.T) {
+	t.Parallel()
+
+	strSet := container.NewMapSet("c", "a", "b")
+	testutil.AssertMarshalJSON(t, `["a","b","c"]`, strSet)
+
+	emptySet := container.NewMapSet[string]()
+	testutil.AssertMarshalJSON(t, `[]`, emptySet)
+	testutil.AssertUnmarshalJSON(t, `["c","a","b","a"]`, container.NewMapSet("a", "b", "c"))
+
+	intSet := container.NewMapSet(10, 1, 2)
+	testutil.AssertMarshalJSON(t, `[1,2,10]`, intSet)
+
+	addrSet := container.NewMapSet(
+		netip.MustParseAddr("10.0.0.1"),
+		netip.MustParseAddr("9.0.0.1"),
+	)
+	testutil.AssertMarshalJSON(t, `["9.0.0.1","10.0.0.1"]`, addrSet)
+
+	// Values without a natural order are sorted by their JSON encodings.
+	type point struct {
+		X int `json:"x"`
+	}
+
+	pointSet := container.NewMapSet(point{X: 2}, point{X: 10}, point{X: 1})
+	testutil.AssertMarshalJSON(t, `[{"x":10},{"x":1},{"x":2}]`, pointSet)
+
+	set := container.NewMapSet(1, 2)
+	err := json.Unmarshal([]byte(`null`), set)
+	require.NoError(t, err)
+
+	assert.Equal(t, container.NewMapSet(1, 2), set)
+
+	err = json.Unmarshal([]byte(`[3]`), set)
+	require.NoError(t, err)
+
+	assert.Equal(t, container.NewMapSet(3), set)
+
+	err = json.Unmarshal([]byte(`["bad"]`), set)
+	require.Error(t, err)
+
+	// An invalid document must not change the set.
+	assert.Equal(t, container.NewMapSet(3), set)
+}
+
+func TestMapSet_encodingField(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Ptr   *container.MapSet[int] `json:"ptr"`
+		Value container.MapSet[int]  `json:"value"`
+	}
+
+	conf := &config{
+		Value: *container.NewMapSet(10, 1, 2),
+	}
+
+	b, err := json.Marshal(conf)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"ptr":null,"value":[1,2,10]}`, string(b))
+
+	got := &config{}
+	err = json.Unmarshal([]byte(`{"ptr":[3],"value":[2,1]}`), got)
+	require.NoError(t, err)
+
+	assert.Equal(t, container.NewMapSet(3), got.Ptr)
+	assert.Equal(t, container.NewMapSet(1, 2), &got.Value)
+}
+
+func TestMapSet_text(t *testing.T) {
+	t.Parallel()
+
+	// NOTE:  Don't use [testutil.AssertMarshalText], since package json prefers
+	// the JSON methods.
+	testCases := []struct {
+		set  encoding.TextMarshaler
+		name string
+		want string
+	}{{
+		set:  container.NewMapSet("c", "a", "b"),
+		name: "strings",
+		want: "a,b,c",
+	}, {
+		set:  container.NewMapSet[string](),
+		name: "empty",
+		want: "",
+	}, {
+		set:  container.NewMapSet(10, 1, 2),
+		name: "ints",
+		want: "1,2,10",
+	}, {
+		set: container.NewMapSet(
+			netip.MustParseAddr("10.0.0.1"),
+			netip.MustParseAddr("9.0.0.1"),
+		),
+		name: "addrs",
+		want: "9.0.0.1,10.0.0.1",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			text, err := tc.set.MarshalText()
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.want, string(text))
+		})
+	}
+
+	strSet := container.NewMapSet("a")
+	require.NoError(t, strSet.UnmarshalText([]byte("c,a,b,a")))
+
+	assert.Equal(t, container.NewMapSet("a", "b", "c"), strSet)
+
+	require.NoError(t, strSet.UnmarshalText([]byte("")))
+
+	assert.Equal(t, container.NewMapSet[string](), strSet)
+
+	addrSet := container.NewMapSet[netip.Addr]()
+	require.NoError(t, addrSet.UnmarshalText([]byte("10.0.0.1,9.0.0.1")))
+
+	assert.Equal(t, container.NewMapSet(
+		netip.MustParseAddr("10.0.0.1"),
+		netip.MustParseAddr("9.0.0.1"),
+	), addrSet)
+
+	_, err := container.NewMapSet("a,b").MarshalText()
+	testutil.AssertErrorMsg(t, `encoding value: value "a,b": contains separator ','`, err)
+
+	_, err = container.NewMapSet(struct{}{}).MarshalText()
+	testutil.AssertErrorMsg(t, `encoding value: type struct {}: no text encoding`, err)
+
+	set := container.NewMapSet(1)
+	err = set.UnmarshalText([]byte("2,bad"))
+	testutil.AssertErrorMsg(
+		t,
+		`at index 1: invalid character 'b' looking for beginning of value`,
+		err,
+	)
+
+	// An invalid text must not change the set.
+	assert.Equal(t, container.NewMapSet(1), set)
+}
+
+func TestMapSet_yaml(t *testing.T) {
+	t.Parallel()
+
+	v, err := container.NewMapSet("c", "a", "b").MarshalYAML()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a", "b", "c"}, v)
+
+	v, err = container.NewMapSet(10, 1, 2).MarshalYAML()
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2, 10}, v)
+
+	set := &container.MapSet[string]{}
+	err = set.UnmarshalYAML(func(v any) (err error) {
+		return json.Unmarshal([]byte(`["b","a"]`), v)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, container.NewMapSet("a", "b"), set)
+}
@@ -0,0 +1,212 @@
+package container
+
+import "iter"
+
+// OrderedMap is a map that keeps its keys in the insertion order.  The order
+// can be changed with [OrderedMap.MoveToFront] and [OrderedMap.MoveToBack].
+// It must be initialized with [NewOrderedMap].
+type OrderedMap[K comparable, V any] struct {
+	m    map[K]*ListElement[KeyValue[K, V]]
+	list *LinkedList[KeyValue[K, V]]
+}
+
+// NewOrderedMap returns a new empty ordered map.
+func NewOrderedMap[K comparable, V any]() (m *OrderedMap[K, V]) {
+	return &OrderedMap[K, V]{
+		m:    map[K]*ListElement[KeyValue[K, V]]{},
+		list: &LinkedList[KeyValue[K, V]]{},
+	}
+}
+
+// Set sets the value for key.  If key is already in m, its position doesn't
+// change; otherwise, key is added to the back.
+func (m *OrderedMap[K, V]) Set(key K, val V) {
+	e, ok := m.m[key]
+	if ok {
+		e.Value.Value = val
+
+		return
+	}
+
+	m.m[key] = m.list.PushBack(KeyValue[K, V]{
+		Key:   key,
+		Value: val,
+	})
+}
+
+// Get returns the value for key.  Calling Get on a nil map returns the zero
+// value and false, just like indexing on a nil map does.
+func (m *OrderedMap[K, V]) Get(key K) (val V, ok bool) {
+	if m == nil {
+		return val, false
+	}
+
+	e, ok := m.m[key]
+	if !ok {
+		return val, false
+	}
+
+	return e.Value.Value, true
+}
+
+// Has returns true if key is in m.  Calling Has on a nil map returns false.
+func (m *OrderedMap[K, V]) Has(key K) (ok bool) {
+	if m != nil {
+		_, ok = m.m[key]
+	}
+
+	return ok
+}
+
+// Delete deletes key from m and returns true if it was there.  Calling Delete
+// on a nil map has no effect.
+func (m *OrderedMap[K, V]) Delete(key K) (ok bool) {
+	if m == nil {
+		return false
+	}
+
+	e, ok := m.m[key]
+	if !ok {
+		return false
+	}
+
+	delete(m.m, key)
+	m.list.Remove(e)
+
+	return true
+}
+
+// Len returns the number of keys in m.  A nil map has a length of zero.
+func (m *OrderedMap[K, V]) Len() (n int) {
+	if m == nil {
+		return 0
+	}
+
+	return len(m.m)
+}
+
+// Clear removes all keys from m.  Calling Clear on a nil map has no effect.
+func (m *OrderedMap[K, V]) Clear() {
+	if m != nil {
+		clear(m.m)
+		m.list.Clear()
+	}
+}
+
+// Front returns the first key and its value.  ok is false if m is empty.
+func (m *OrderedMap[K, V]) Front() (key K, val V, ok bool) {
+	if m.Len() == 0 {
+		return key, val, false
+	}
+
+	kv := m.list.Front().Value
+
+	return kv.Key, kv.Value, true
+}
+
+// Back returns the last key and its value.  ok is false if m is empty.
+func (m *OrderedMap[K, V]) Back() (key K, val V, ok bool) {
+	if m.Len() == 0 {
+		return key, val, false
+	}
+
+	kv := m.list.Back().Value
+
+	return kv.Key, kv.Value, true
+}
+
+// MoveToFront moves key to the front and returns true if key is in m.  Calling
+// MoveToFront on a nil map returns false.
+func (m *OrderedMap[K, V]) MoveToFront(key K) (ok bool) {
+	if m == nil {
+		return false
+	}
+
+	e, ok := m.m[key]
+	if ok {
+		m.list.MoveToFront(e)
+	}
+
+	return ok
+}
+
+// MoveToBack moves key to the back and returns true if key is in m.  Calling
+// MoveToBack on a nil map returns false.
+func (m *OrderedMap[K, V]) MoveToBack(key K) (ok bool) {
+	if m == nil {
+		return false
+	}
+
+	e, ok := m.m[key]
+	if ok {
+		m.list.MoveToBack(e)
+	}
+
+	return ok
+}
+
+// Range calls f with each key and value of m from the front to the back until
+// f returns false.  f may delete the current key from m.  Calling Range on a
+// nil map has no effect.
+func (m *OrderedMap[K, V]) Range(f func(key K, val V) (cont bool)) {
+	if m == nil {
+		return
+	}
+
+	m.list.Range(func(kv KeyValue[K, V]) (cont bool) {
+		return f(kv.Key, kv.Value)
+	})
+}
+
+// ReverseRange is like [OrderedMap.Range] but from the back to the front.
+func (m *OrderedMap[K, V]) ReverseRange(f func(key K, val V) (cont bool)) {
+	if m == nil {
+		return
+	}
+
+	m.list.ReverseRange(func(kv KeyValue[K, V]) (cont bool) {
+		return f(kv.Key, kv.Value)
+	})
+}
+
+// All returns an iterator over the keys and values of m from the front to the
+// back.  The current key may be deleted from m during the iteration.
+func (m *OrderedMap[K, V]) All() (seq iter.Seq2[K, V]) {
+	return m.Range
+}
+
+// Backward returns an iterator over the keys and values of m from the back to
+// the front.  The current key may be deleted from m during the iteration.
+func (m *OrderedMap[K, V]) Backward() (seq iter.Seq2[K, V]) {
+	return m.ReverseRange
+}
+
+// Keys returns all keys of m from the front to the back.  Keys returns nil if
+// m is empty.
+func (m *OrderedMap[K, V]) Keys() (keys []K) {
+	if m.Len() == 0 {
+		return nil
+	}
+
+	keys = make([]K, 0, len(m.m))
+	for e := m.list.Front(); e != nil; e = e.Next() {
+		keys = append(keys, e.Value.Key)
+	}
+
+	return keys
+}
+
+// Values returns all values of m from the front to the back.  Values returns
+// nil if m is empty.
+func (m *OrderedMap[K, V]) Values() (values []V) {
+	if m.Len() == 0 {
+		return nil
+	}
+
+	values = make([]V, 0, len(m.m))
+	for e := m.list.Front(); e != nil; e = e.Next() {
+		values = append(values, e.Value.Value)
+	}
+
+	return values
+}
@@ -0,0 +1,70 @@
+package container_test
+
+import (
+	"fmt"
+
+	"github.com/AdguardTeam/golibs/container"
+)
+
+func ExampleOrderedMap() {
+	m := container.NewOrderedMap[string, int]()
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+
+	// Setting an existing key doesn't change its position.
+	m.Set("c", 30)
+	fmt.Println(m.Keys(), m.Values())
+
+	v, ok := m.Get("a")
+	fmt.Println(v, ok)
+
+	m.MoveToBack("c")
+	m.MoveToFront("b")
+	fmt.Println(m.Keys())
+
+	k, v, ok := m.Front()
+	fmt.Println(k, v, ok)
+
+	fmt.Println(m.Delete("a"), m.Delete("a"), m.Len())
+
+	for k, v := range m.Range {
+		fmt.Println(k, v)
+	}
+
+	m.Clear()
+	fmt.Println(m.Keys(), m.Len())
+
+	// Output:
+	// [c a b] [30 1 2]
+	// 1 true
+	// [b a c]
+	// b 2 true
+	// true false 2
+	// b 2
+	// c 30
+	// [] 0
+}
+
+func ExampleOrderedMap_All() {
+	m := container.NewOrderedMap[string, int]()
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+
+	for k, v := range m.All() {
+		fmt.Println(k, v)
+	}
+
+	for k := range m.Backward() {
+		fmt.Println(k)
+	}
+
+	// Output:
+	// c 3
+	// a 1
+	// b 2
+	// b
+	// a
+	// c
+}
@@ -0,0 +1,98 @@
+package container_test
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedMap_nil(t *testing.T) {
+	t.Parallel()
+
+	var m *container.OrderedMap[string, int]
+
+	v, ok := m.Get("a")
+	assert.Zero(t, v)
+	assert.False(t, ok)
+
+	assert.False(t, m.Has("a"))
+	assert.False(t, m.Delete("a"))
+	assert.Zero(t, m.Len())
+	assert.Nil(t, m.Keys())
+	assert.Nil(t, m.Values())
+
+	_, _, ok = m.Back()
+	assert.False(t, ok)
+
+	assert.False(t, m.MoveToFront("a"))
+	assert.False(t, m.MoveToBack("a"))
+
+	assert.NotPanics(t, m.Clear)
+	assert.NotPanics(t, func() {
+		m.Range(func(_ string, _ int) (cont bool) { panic("unexpected call") })
+	})
+
+	assert.Empty(t, maps.Collect(m.All()))
+	assert.Empty(t, maps.Collect(m.Backward()))
+}
+
+func TestOrderedMap_All(t *testing.T) {
+	t.Parallel()
+
+	m := container.NewOrderedMap[string, int]()
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+
+	var keys []string
+	for k, v := range m.All() {
+		if v == 2 {
+			break
+		}
+
+		keys = append(keys, k)
+	}
+
+	assert.Equal(t, []string{"c", "a"}, keys)
+
+	keys = keys[:0]
+	for k := range m.Backward() {
+		keys = append(keys, k)
+	}
+
+	assert.Equal(t, []string{"b", "a", "c"}, keys)
+}
+
+func TestOrderedMap_Range_delete(t *testing.T) {
+	t.Parallel()
+
+	m := container.NewOrderedMap[int, string]()
+	for i, s := range []string{"a", "b", "c", "d"} {
+		m.Set(i, s)
+	}
+
+	for k := range m.Range {
+		if k%2 == 1 {
+			m.Delete(k)
+		}
+	}
+
+	assert.Equal(t, []int{0, 2}, m.Keys())
+
+	var keys []int
+	for k := range m.ReverseRange {
+		keys = append(keys, k)
+	}
+
+	assert.Equal(t, []int{2, 0}, keys)
+
+	k, v, ok := m.Back()
+	assert.True(t, ok)
+	assert.Equal(t, 2, k)
+	assert.Equal(t, "c", v)
+
+	assert.False(t, m.MoveToFront(1))
+	assert.False(t, m.MoveToBack(1))
+}
@@ -0,0 +1,401 @@
+package container
+
+import (
+	"hash/maphash"
+	"math/bits"
+	"slices"
+)
+
+// HAMT parameters.
+const (
+	// hamtBits is the number of bits of the hash used on each level of the
+	// trie.
+	hamtBits = 5
+
+	// hamtMask is used to get the index within a node on a level.
+	hamtMask = 1<<hamtBits - 1
+)
+
+// PersistentMap is an immutable map implemented as a hash array mapped trie.
+// The methods that change the map return a new map that shares most of its
+// storage with the original one, so the changes are cheap, and the original
+// map can still be used.  A nil *PersistentMap and the zero value are empty
+// maps.  It is safe for concurrent use, which makes it useful for snapshots of
+// data that is updated through an [sync/atomic.Pointer].
+type PersistentMap[K comparable, V any] struct {
+	root *hamtNode[K, V]
+	seed maphash.Seed
+	len  int
+}
+
+// hamtNode is a node of a [PersistentMap].  Nodes are never modified after
+// they have been published.
+type hamtNode[K comparable, V any] struct {
+	// entries are the entries of the node in the order of their indexes.
+	entries []hamtEntry[K, V]
+
+	// bitmap has a bit set for each index that has an entry.
+	bitmap uint32
+}
+
+// hamtEntry is an entry of a [hamtNode].  Exactly one of the fields is not
+// nil.
+type hamtEntry[K comparable, V any] struct {
+	node *hamtNode[K, V]
+	leaf *hamtLeaf[K, V]
+}
+
+// hamtLeaf contains the key-value pairs with the same hash, usually only one.
+type hamtLeaf[K comparable, V any] struct {
+	kvs  []KeyValue[K, V]
+	hash uint64
+}
+
+// NewPersistentMap returns a new empty *PersistentMap.
+func NewPersistentMap[K comparable, V any]() (m *PersistentMap[K, V]) {
+	return &PersistentMap[K, V]{
+		root: &hamtNode[K, V]{},
+		seed: maphash.MakeSeed(),
+	}
+}
+
+// Len returns the number of keys in m.  A nil map has a length of zero.
+func (m *PersistentMap[K, V]) Len() (n int) {
+	if m == nil {
+		return 0
+	}
+
+	return m.len
+}
+
+// Get returns the value for key.  ok is false if key is not in m.
+func (m *PersistentMap[K, V]) Get(key K) (v V, ok bool) {
+	if m.Len() == 0 {
+		return v, false
+	}
+
+	return m.root.get(maphash.Comparable(m.seed, key), 0, key)
+}
+
+// Has returns true if key is in m.
+func (m *PersistentMap[K, V]) Has(key K) (ok bool) {
+	_, ok = m.Get(key)
+
+	return ok
+}
+
+// With returns a map that contains all keys of m and key with the value v.  m
+// is not changed.  Calling With on a nil or zero map returns a new map.
+func (m *PersistentMap[K, V]) With(key K, v V) (res *PersistentMap[K, V]) {
+	if m == nil || m.root == nil {
+		m = NewPersistentMap[K, V]()
+	}
+
+	root, added := m.root.with(maphash.Comparable(m.seed, key), 0, key, v)
+	res = &PersistentMap[K, V]{
+		root: root,
+		seed: m.seed,
+		len:  m.len,
+	}
+
+	if added {
+		res.len++
+	}
+
+	return res
+}
+
+// Without returns a map that contains all keys of m except key.  m is not
+// changed.  If key is not in m, Without returns m.
+func (m *PersistentMap[K, V]) Without(key K) (res *PersistentMap[K, V]) {
+	if m.Len() == 0 {
+		return m
+	}
+
+	root, removed := m.root.without(maphash.Comparable(m.seed, key), 0, key)
+	if !removed {
+		return m
+	}
+
+	return &PersistentMap[K, V]{
+		root: root,
+		seed: m.seed,
+		len:  m.len - 1,
+	}
+}
+
+// Range calls f with each key and value of m until f returns false.  The order
+// of the keys is undefined.  Calling Range on a nil map has no effect.
+func (m *PersistentMap[K, V]) Range(f func(key K, v V) (cont bool)) {
+	if m.Len() > 0 {
+		_ = m.root.rangeKVs(f)
+	}
+}
+
+// KeySet returns a new *MapSet with the keys of m.
+func (m *PersistentMap[K, V]) KeySet() (set *MapSet[K]) {
+	set = &MapSet[K]{
+		m: make(map[K]unit, m.Len()),
+	}
+
+	m.Range(func(key K, _ V) (cont bool) {
+		set.m[key] = unit{}
+
+		return true
+	})
+
+	return set
+}
+
+// position returns the bit for hash on the level with the given shift and the
+// position of the corresponding entry in n.entries.
+func (n *hamtNode[K, V]) position(hash uint64, shift uint) (bit uint32, pos int) {
+	bit = 1 << ((hash >> shift) & hamtMask)
+
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// get returns the value for key with the hash on the level with the given
+// shift.
+func (n *hamtNode[K, V]) get(hash uint64, shift uint, key K) (v V, ok bool) {
+	for {
+		bit, pos := n.position(hash, shift)
+		if n.bitmap&bit == 0 {
+			return v, false
+		}
+
+		e := n.entries[pos]
+		if e.node == nil {
+			return e.leaf.get(hash, key)
+		}
+
+		n, shift = e.node, shift+hamtBits
+	}
+}
+
+// get returns the value for key with the hash.
+func (l *hamtLeaf[K, V]) get(hash uint64, key K) (v V, ok bool) {
+	if l.hash != hash {
+		return v, false
+	}
+
+	for _, kv := range l.kvs {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+
+	return v, false
+}
+
+// withEntry returns a copy of n with the entry at pos replaced with e.
+func (n *hamtNode[K, V]) withEntry(pos int, e hamtEntry[K, V]) (res *hamtNode[K, V]) {
+	res = &hamtNode[K, V]{
+		entries: slices.Clone(n.entries),
+		bitmap:  n.bitmap,
+	}
+
+	res.entries[pos] = e
+
+	return res
+}
+
+// with returns a copy of n with key set to v.  added is true if key wasn't in
+// n.
+func (n *hamtNode[K, V]) with(
+	hash uint64,
+	shift uint,
+	key K,
+	v V,
+) (res *hamtNode[K, V], added bool) {
+	bit, pos := n.position(hash, shift)
+	if n.bitmap&bit == 0 {
+		leaf := &hamtLeaf[K, V]{
+			kvs:  []KeyValue[K, V]{{Key: key, Value: v}},
+			hash: hash,
+		}
+
+		entries := slices.Insert(slices.Clone(n.entries), pos, hamtEntry[K, V]{leaf: leaf})
+
+		return &hamtNode[K, V]{
+			entries: entries,
+			bitmap:  n.bitmap | bit,
+		}, true
+	}
+
+	e := n.entries[pos]
+	if e.node != nil {
+		var child *hamtNode[K, V]
+		child, added = e.node.with(hash, shift+hamtBits, key, v)
+
+		return n.withEntry(pos, hamtEntry[K, V]{node: child}), added
+	}
+
+	if e.leaf.hash == hash {
+		var leaf *hamtLeaf[K, V]
+		leaf, added = e.leaf.with(key, v)
+
+		return n.withEntry(pos, hamtEntry[K, V]{leaf: leaf}), added
+	}
+
+	// Different hashes with the same index on this level, so push both leaves
+	// down.
+	leaf := &hamtLeaf[K, V]{
+		kvs:  []KeyValue[K, V]{{Key: key, Value: v}},
+		hash: hash,
+	}
+
+	child := newHAMTNodeFromLeaves(e.leaf, leaf, shift+hamtBits)
+
+	return n.withEntry(pos, hamtEntry[K, V]{node: child}), true
+}
+
+// with returns a copy of l with key set to v.  added is true if key wasn't in
+// l.
+func (l *hamtLeaf[K, V]) with(key K, v V) (res *hamtLeaf[K, V], added bool) {
+	kv := KeyValue[K, V]{Key: key, Value: v}
+	res = &hamtLeaf[K, V]{
+		kvs:  slices.Clone(l.kvs),
+		hash: l.hash,
+	}
+
+	i := slices.IndexFunc(res.kvs, func(other KeyValue[K, V]) (ok bool) {
+		return other.Key == key
+	})
+	if i >= 0 {
+		res.kvs[i] = kv
+
+		return res, false
+	}
+
+	res.kvs = append(res.kvs, kv)
+
+	return res, true
+}
+
+// newHAMTNodeFromLeaves returns a new node on the level with the given shift
+// that contains a and b, which must have different hashes.
+func newHAMTNodeFromLeaves[K comparable, V any](
+	a *hamtLeaf[K, V],
+	b *hamtLeaf[K, V],
+	shift uint,
+) (n *hamtNode[K, V]) {
+	aIdx, bIdx := (a.hash>>shift)&hamtMask, (b.hash>>shift)&hamtMask
+	if aIdx == bIdx {
+		child := newHAMTNodeFromLeaves(a, b, shift+hamtBits)
+
+		return &hamtNode[K, V]{
+			entries: []hamtEntry[K, V]{{node: child}},
+			bitmap:  1 << aIdx,
+		}
+	}
+
+	if aIdx > bIdx {
+		a, b = b, a
+	}
+
+	return &hamtNode[K, V]{
+		entries: []hamtEntry[K, V]{{leaf: a}, {leaf: b}},
+		bitmap:  1<<aIdx | 1<<bIdx,
+	}
+}
+
+// without returns a copy of n without key.  removed is false if key wasn't in
+// n, in which case res is n.
+func (n *hamtNode[K, V]) without(
+	hash uint64,
+	shift uint,
+	key K,
+) (res *hamtNode[K, V], removed bool) {
+	bit, pos := n.position(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+
+	e := n.entries[pos]
+	if e.node == nil {
+		var leaf *hamtLeaf[K, V]
+		leaf, removed = e.leaf.without(hash, key)
+		if !removed {
+			return n, false
+		} else if leaf != nil {
+			return n.withEntry(pos, hamtEntry[K, V]{leaf: leaf}), true
+		}
+
+		return n.withoutEntry(pos, bit), true
+	}
+
+	child, removed := e.node.without(hash, shift+hamtBits, key)
+	if !removed {
+		return n, false
+	}
+
+	switch {
+	case len(child.entries) == 0:
+		return n.withoutEntry(pos, bit), true
+	case len(child.entries) == 1 && child.entries[0].leaf != nil:
+		// Pull the only leaf up to keep the trie compact.
+		return n.withEntry(pos, child.entries[0]), true
+	default:
+		return n.withEntry(pos, hamtEntry[K, V]{node: child}), true
+	}
+}
+
+// withoutEntry returns a copy of n without the entry at pos with the given
+// bit.
+func (n *hamtNode[K, V]) withoutEntry(pos int, bit uint32) (res *hamtNode[K, V]) {
+	return &hamtNode[K, V]{
+		entries: slices.Delete(slices.Clone(n.entries), pos, pos+1),
+		bitmap:  n.bitmap &^ bit,
+	}
+}
+
+// without returns a copy of l without key.  removed is false if key wasn't in
+// l.  res is nil if l only contained key.
+func (l *hamtLeaf[K, V]) without(hash uint64, key K) (res *hamtLeaf[K, V], removed bool) {
+	if l.hash != hash {
+		return l, false
+	}
+
+	i := slices.IndexFunc(l.kvs, func(kv KeyValue[K, V]) (ok bool) { return kv.Key == key })
+	if i < 0 {
+		return l, false
+	} else if len(l.kvs) == 1 {
+		return nil, true
+	}
+
+	return &hamtLeaf[K, V]{
+		kvs:  slices.Delete(slices.Clone(l.kvs), i, i+1),
+		hash: l.hash,
+	}, true
+}
+
+// rangeKVs calls f with each key and value of n until f returns false.  cont
+// is false if f returned false.
+func (n *hamtNode[K, V]) rangeKVs(f func(key K, v V) (cont bool)) (cont bool) {
+	for _, e := range n.entries {
+		if e.node != nil {
+			cont = e.node.rangeKVs(f)
+		} else {
+			cont = e.leaf.rangeKVs(f)
+		}
+
+		if !cont {
+			return false
+		}
+	}
+
+	return true
+}
+
+// rangeKVs calls f with each key and value of l until f returns false.  cont
+// is false if f returned false.
+func (l *hamtLeaf[K, V]) rangeKVs(f func(key K, v V) (cont bool)) (cont bool) {
+	for _, kv := range l.kvs {
+		if !f(kv.Key, kv.Value) {
+			return false
+		}
+	}
+
+	return true
+}
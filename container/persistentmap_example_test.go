@@ -0,0 +1,52 @@
+package container_test
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/AdguardTeam/golibs/container"
+)
+
+func ExamplePersistentMap() {
+	type config struct {
+		upstreams *container.PersistentMap[string, string]
+	}
+
+	conf := &atomic.Pointer[config]{}
+	conf.Store(&config{
+		upstreams: container.NewPersistentMap[string, string]().
+			With("example.com", "1.1.1.1").
+			With("example.org", "8.8.8.8"),
+	})
+
+	// Readers keep using their snapshot while a writer publishes an update.
+	snapshot := conf.Load()
+
+	conf.Store(&config{
+		upstreams: snapshot.upstreams.
+			With("example.com", "9.9.9.9").
+			Without("example.org"),
+	})
+
+	old, _ := snapshot.upstreams.Get("example.com")
+	fmt.Println(old, snapshot.upstreams.Len())
+
+	cur, _ := conf.Load().upstreams.Get("example.com")
+	fmt.Println(cur, conf.Load().upstreams.Len())
+
+	// Output:
+	// 1.1.1.1 2
+	// 9.9.9.9 1
+}
+
+func ExamplePersistentSet() {
+	set := container.NewPersistentSetFromMapSet(container.NewMapSet("a", "b"))
+	updated := set.With("c").Without("a")
+
+	fmt.Println(container.MapSetToString(set.MapSet()))
+	fmt.Println(container.MapSetToString(updated.MapSet()))
+
+	// Output:
+	// [a b]
+	// [b c]
+}
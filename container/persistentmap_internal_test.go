@@ -0,0 +1,62 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHAMTNode_collisions(t *testing.T) {
+	t.Parallel()
+
+	// Use the same hash for all keys to emulate full collisions.
+	const hash = 0x1234_5678_9abc_def0
+
+	n := &hamtNode[string, int]{}
+	n, added := n.with(hash, 0, "a", 1)
+	require.True(t, added)
+
+	n, added = n.with(hash, 0, "b", 2)
+	require.True(t, added)
+
+	n, added = n.with(hash, 0, "b", 3)
+	require.False(t, added)
+
+	// A key with a hash that only differs in the last bits must be pushed down
+	// to the last level.
+	const otherHash = hash ^ 1<<63
+
+	n, added = n.with(otherHash, 0, "c", 4)
+	require.True(t, added)
+
+	for key, want := range map[string]int{"a": 1, "b": 3} {
+		v, ok := n.get(hash, 0, key)
+		require.True(t, ok)
+
+		assert.Equal(t, want, v)
+	}
+
+	v, ok := n.get(otherHash, 0, "c")
+	require.True(t, ok)
+
+	assert.Equal(t, 4, v)
+
+	_, ok = n.get(hash, 0, "c")
+	assert.False(t, ok)
+
+	n, removed := n.without(hash, 0, "a")
+	require.True(t, removed)
+
+	_, removed = n.without(hash, 0, "a")
+	require.False(t, removed)
+
+	n, removed = n.without(otherHash, 0, "c")
+	require.True(t, removed)
+
+	// The remaining leaf must be pulled up to the root.
+	require.Len(t, n.entries, 1)
+	require.NotNil(t, n.entries[0].leaf)
+
+	assert.Equal(t, []KeyValue[string, int]{{Key: "b", Value: 3}}, n.entries[0].leaf.kvs)
+}
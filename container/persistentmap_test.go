@@ -0,0 +1,196 @@
+package container_test
+
+import (
+	"maps"
+	"math/rand/v2"
+	"strconv"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collectPersistentMap returns the contents of m as a Go map.
+func collectPersistentMap[K comparable, V any](m *container.PersistentMap[K, V]) (res map[K]V) {
+	res = map[K]V{}
+	m.Range(func(k K, v V) (cont bool) {
+		res[k] = v
+
+		return true
+	})
+
+	return res
+}
+
+func TestPersistentMap(t *testing.T) {
+	t.Parallel()
+
+	type snapshot struct {
+		m    *container.PersistentMap[int, int]
+		want map[int]int
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	m := container.NewPersistentMap[int, int]()
+	want := map[int]int{}
+
+	var snapshots []snapshot
+	for i := range 10_000 {
+		k := rng.IntN(2_000)
+		if rng.IntN(3) == 0 {
+			m = m.Without(k)
+			delete(want, k)
+		} else {
+			m = m.With(k, i)
+			want[k] = i
+		}
+
+		if i%1_000 == 0 {
+			snapshots = append(snapshots, snapshot{m: m, want: maps.Clone(want)})
+		}
+	}
+
+	snapshots = append(snapshots, snapshot{m: m, want: want})
+
+	// All snapshots must stay unchanged.
+	for i, s := range snapshots {
+		require.Equal(t, len(s.want), s.m.Len(), "snapshot %d", i)
+		require.Equal(t, s.want, collectPersistentMap(s.m), "snapshot %d", i)
+
+		for k, v := range s.want {
+			got, ok := s.m.Get(k)
+			require.True(t, ok)
+			require.Equal(t, v, got)
+		}
+	}
+
+	// Delete everything.
+	for k := range want {
+		m = m.Without(k)
+	}
+
+	assert.Zero(t, m.Len())
+	assert.Empty(t, collectPersistentMap(m))
+}
+
+func TestPersistentMap_nil(t *testing.T) {
+	t.Parallel()
+
+	var m *container.PersistentMap[string, int]
+	assert.Zero(t, m.Len())
+	assert.False(t, m.Has("a"))
+	assert.Nil(t, m.Without("a"))
+	assert.Empty(t, collectPersistentMap(m))
+	assert.Zero(t, m.KeySet().Len())
+
+	withA := m.With("a", 1)
+	v, ok := withA.Get("a")
+	require.True(t, ok)
+
+	assert.Equal(t, 1, v)
+	assert.Same(t, withA, withA.Without("b"))
+}
+
+func TestPersistentMap_zero(t *testing.T) {
+	t.Parallel()
+
+	m := &container.PersistentMap[string, int]{}
+	assert.Zero(t, m.Len())
+	assert.False(t, m.Has("a"))
+	assert.Same(t, m, m.Without("a"))
+	assert.Empty(t, collectPersistentMap(m))
+
+	withA := m.With("a", 1)
+	v, ok := withA.Get("a")
+	require.True(t, ok)
+
+	assert.Equal(t, 1, v)
+	assert.Equal(t, 1, withA.Len())
+	assert.Zero(t, m.Len())
+}
+
+func TestPersistentSet(t *testing.T) {
+	t.Parallel()
+
+	ms := container.NewMapSet(1, 2, 3)
+	ps := container.NewPersistentSetFromMapSet(ms)
+
+	ps2 := ps.With(4).Without(1)
+	assert.Equal(t, 3, ps.Len())
+	assert.True(t, ps.Has(1))
+	assert.False(t, ps.Has(4))
+
+	assert.True(t, ps2.MapSet().Equal(container.NewMapSet(2, 3, 4)))
+	assert.True(t, ps.MapSet().Equal(ms))
+	assert.Same(t, ps2, ps2.Without(1))
+
+	var nilSet *container.PersistentSet[int]
+	assert.Zero(t, nilSet.Len())
+	assert.True(t, nilSet.With(1).Has(1))
+	assert.Nil(t, nilSet.Without(1))
+}
+
+func BenchmarkPersistentMap_With(b *testing.B) {
+	const n = 100_000
+
+	m := container.NewPersistentMap[string, int]()
+	keys := make([]string, 0, n)
+	for i := range n {
+		k := strconv.Itoa(i)
+		keys = append(keys, k)
+		m = m.With(k, i)
+	}
+
+	var (
+		i   int
+		res *container.PersistentMap[string, int]
+	)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		res = m.With(keys[i%n], i)
+		i++
+	}
+
+	assert.Equal(b, n, res.Len())
+
+	// Most recent results:
+	//	goos: linux
+	//	goarch: amd64
+	//	pkg: github.com/AdguardTeam/golibs/container
+	//	cpu: Intel(R) Xeon(R) Processor
+	//	BenchmarkPersistentMap_With 	  186332	      6591 ns/op	    1793 B/op	      11 allocs/op
+}
+
+func BenchmarkPersistentMap_Get(b *testing.B) {
+	const n = 100_000
+
+	m := container.NewPersistentMap[string, int]()
+	keys := make([]string, 0, n)
+	for i := range n {
+		k := strconv.Itoa(i)
+		keys = append(keys, k)
+		m = m.With(k, i)
+	}
+
+	var (
+		i  int
+		ok bool
+	)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_, ok = m.Get(keys[i%n])
+		i++
+	}
+
+	assert.True(b, ok)
+
+	// Most recent results:
+	//	goos: linux
+	//	goarch: amd64
+	//	pkg: github.com/AdguardTeam/golibs/container
+	//	cpu: Intel(R) Xeon(R) Processor
+	//	BenchmarkPersistentMap_Get  	 5230386	       213.1 ns/op	       0 B/op	       0 allocs/op
+}
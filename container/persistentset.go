@@ -0,0 +1,88 @@
+package container
+
+// PersistentSet is an immutable set based on a [PersistentMap].  A nil
+// *PersistentSet is an empty set.  It is safe for concurrent use.
+type PersistentSet[T comparable] struct {
+	m *PersistentMap[T, unit]
+}
+
+// NewPersistentSet returns a new *PersistentSet containing values.
+func NewPersistentSet[T comparable](values ...T) (set *PersistentSet[T]) {
+	m := NewPersistentMap[T, unit]()
+	for _, v := range values {
+		m = m.With(v, unit{})
+	}
+
+	return &PersistentSet[T]{
+		m: m,
+	}
+}
+
+// NewPersistentSetFromMapSet returns a new *PersistentSet containing the values
+// of set.
+func NewPersistentSetFromMapSet[T comparable](set *MapSet[T]) (ps *PersistentSet[T]) {
+	m := NewPersistentMap[T, unit]()
+	set.Range(func(v T) (cont bool) {
+		m = m.With(v, unit{})
+
+		return true
+	})
+
+	return &PersistentSet[T]{
+		m: m,
+	}
+}
+
+// persistentMap returns the underlying map of set or nil if set is nil.
+func (set *PersistentSet[T]) persistentMap() (m *PersistentMap[T, unit]) {
+	if set == nil {
+		return nil
+	}
+
+	return set.m
+}
+
+// Has returns true if v is in set.
+func (set *PersistentSet[T]) Has(v T) (ok bool) {
+	return set.persistentMap().Has(v)
+}
+
+// Len returns the number of values in set.  A nil set has a length of zero.
+func (set *PersistentSet[T]) Len() (n int) {
+	return set.persistentMap().Len()
+}
+
+// With returns a set that contains all values of set and v.  set is not
+// changed.
+func (set *PersistentSet[T]) With(v T) (res *PersistentSet[T]) {
+	return &PersistentSet[T]{
+		m: set.persistentMap().With(v, unit{}),
+	}
+}
+
+// Without returns a set that contains all values of set except v.  set is not
+// changed.  If v is not in set, Without returns set.
+func (set *PersistentSet[T]) Without(v T) (res *PersistentSet[T]) {
+	m := set.persistentMap()
+	withoutV := m.Without(v)
+	if withoutV == m {
+		return set
+	}
+
+	return &PersistentSet[T]{
+		m: withoutV,
+	}
+}
+
+// Range calls f with each value of set until f returns false.  The order of the
+// values is undefined.  Calling Range on a nil set has no effect.
+func (set *PersistentSet[T]) Range(f func(v T) (cont bool)) {
+	set.persistentMap().Range(func(v T, _ unit) (cont bool) {
+		return f(v)
+	})
+}
+
+// MapSet returns a new *MapSet with the values of set.
+func (set *PersistentSet[T]) MapSet() (ms *MapSet[T]) {
+	return set.persistentMap().KeySet()
+}
@@ -0,0 +1,218 @@
+package container
+
+import "iter"
+
+// PriorityQueueHandle is a handle of a value in a [PriorityQueue].  It can be
+// used to update or remove the value.
+type PriorityQueueHandle[T any] struct {
+	// queue is the queue to which the value belongs.  It is nil if the value
+	// isn't in a queue.
+	queue *PriorityQueue[T]
+
+	value T
+
+	// idx is the index of the handle in the heap.
+	idx int
+}
+
+// Value returns the value of h.
+func (h *PriorityQueueHandle[T]) Value() (v T) {
+	return h.value
+}
+
+// PriorityQueue is a min-heap based priority queue.  The value with the lowest
+// priority according to the comparison function is always at the front.  It
+// must be initialized with [NewPriorityQueue].  It is not safe for concurrent
+// use.
+//
+// The methods that accept handles do nothing if the handle doesn't belong to
+// the queue.
+type PriorityQueue[T any] struct {
+	compare func(a, b T) (res int)
+	heap    []*PriorityQueueHandle[T]
+}
+
+// NewPriorityQueue returns a new empty priority queue.  compare must not be nil
+// and must return a negative number if a has a lower priority value than b, a
+// positive number if a has a higher one, and zero otherwise, like the
+// functions for [slices.SortFunc] do.  Use a reversed comparison to get a
+// max-heap.
+func NewPriorityQueue[T any](compare func(a, b T) (res int)) (q *PriorityQueue[T]) {
+	return &PriorityQueue[T]{
+		compare: compare,
+	}
+}
+
+// Len returns the number of values in q.  A nil queue has a length of zero.
+func (q *PriorityQueue[T]) Len() (n int) {
+	if q == nil {
+		return 0
+	}
+
+	return len(q.heap)
+}
+
+// Push adds v to q and returns its handle.
+func (q *PriorityQueue[T]) Push(v T) (h *PriorityQueueHandle[T]) {
+	h = &PriorityQueueHandle[T]{
+		queue: q,
+		value: v,
+		idx:   len(q.heap),
+	}
+
+	q.heap = append(q.heap, h)
+	q.up(h.idx)
+
+	return h
+}
+
+// Peek returns the value at the front of q without removing it.  ok is false
+// if q is empty.
+func (q *PriorityQueue[T]) Peek() (v T, ok bool) {
+	if q.Len() == 0 {
+		return v, false
+	}
+
+	return q.heap[0].value, true
+}
+
+// Pop removes the value at the front of q and returns it.  ok is false if q is
+// empty.
+func (q *PriorityQueue[T]) Pop() (v T, ok bool) {
+	if q.Len() == 0 {
+		return v, false
+	}
+
+	return q.remove(0), true
+}
+
+// Update sets the value of h to v and restores the order of q.  ok is false if
+// h is nil or doesn't belong to q.
+func (q *PriorityQueue[T]) Update(h *PriorityQueueHandle[T], v T) (ok bool) {
+	if !q.owns(h) {
+		return false
+	}
+
+	h.value = v
+	if !q.down(h.idx) {
+		q.up(h.idx)
+	}
+
+	return true
+}
+
+// Remove removes the value of h from q and returns it.  ok is false if h is
+// nil or doesn't belong to q.
+func (q *PriorityQueue[T]) Remove(h *PriorityQueueHandle[T]) (v T, ok bool) {
+	if !q.owns(h) {
+		return v, false
+	}
+
+	return q.remove(h.idx), true
+}
+
+// owns returns true if h is not nil and belongs to q.  Since the handles that
+// have been removed don't belong to any queue, q is not nil if ok is true.
+func (q *PriorityQueue[T]) owns(h *PriorityQueueHandle[T]) (ok bool) {
+	return h != nil && h.queue != nil && h.queue == q
+}
+
+// Clear removes all values from q.  Calling Clear on a nil queue has no effect.
+func (q *PriorityQueue[T]) Clear() {
+	if q == nil {
+		return
+	}
+
+	for _, h := range q.heap {
+		h.queue = nil
+	}
+
+	clear(q.heap)
+	q.heap = q.heap[:0]
+}
+
+// Drain returns an iterator that removes the values from q in the order of
+// their priority.  The values that aren't consumed before the iteration stops
+// remain in q.
+func (q *PriorityQueue[T]) Drain() (seq iter.Seq[T]) {
+	return func(yield func(v T) (cont bool)) {
+		for {
+			v, ok := q.Pop()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// remove removes the handle at index i from the heap and returns its value.
+func (q *PriorityQueue[T]) remove(i int) (v T) {
+	h := q.heap[i]
+	last := len(q.heap) - 1
+	if i != last {
+		q.swap(i, last)
+	}
+
+	q.heap[last] = nil
+	q.heap = q.heap[:last]
+
+	if i != last && !q.down(i) {
+		q.up(i)
+	}
+
+	h.queue = nil
+	h.idx = -1
+
+	return h.value
+}
+
+// less returns true if the value at index i has a lower priority value than
+// the one at index j.
+func (q *PriorityQueue[T]) less(i, j int) (ok bool) {
+	return q.compare(q.heap[i].value, q.heap[j].value) < 0
+}
+
+// swap swaps the handles at indexes i and j.
+func (q *PriorityQueue[T]) swap(i, j int) {
+	q.heap[i], q.heap[j] = q.heap[j], q.heap[i]
+	q.heap[i].idx = i
+	q.heap[j].idx = j
+}
+
+// up moves the handle at index i up the heap until the heap is ordered.
+func (q *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(i, parent) {
+			return
+		}
+
+		q.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the handle at index i down the heap until the heap is ordered.
+// moved is true if the handle has been moved.
+func (q *PriorityQueue[T]) down(i int) (moved bool) {
+	start, n := i, len(q.heap)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+
+		if right := child + 1; right < n && q.less(right, child) {
+			child = right
+		}
+
+		if !q.less(child, i) {
+			break
+		}
+
+		q.swap(i, child)
+		i = child
+	}
+
+	return i > start
+}
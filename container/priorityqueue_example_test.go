@@ -0,0 +1,40 @@
+package container_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AdguardTeam/golibs/container"
+)
+
+func ExamplePriorityQueue() {
+	type job struct {
+		deadline time.Time
+		name     string
+	}
+
+	q := container.NewPriorityQueue(func(a, b job) (res int) {
+		return a.deadline.Compare(b.deadline)
+	})
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	q.Push(job{deadline: now.Add(3 * time.Minute), name: "hosts"})
+	q.Push(job{deadline: now.Add(1 * time.Minute), name: "filters"})
+	h := q.Push(job{deadline: now.Add(2 * time.Minute), name: "services"})
+
+	next, _ := q.Peek()
+	fmt.Println("next:", next.name)
+
+	// Postpone the job.
+	q.Update(h, job{deadline: now.Add(5 * time.Minute), name: h.Value().name})
+
+	for j := range q.Drain() {
+		fmt.Println(j.deadline.Sub(now), j.name)
+	}
+
+	// Output:
+	// next: filters
+	// 1m0s filters
+	// 3m0s hosts
+	// 5m0s services
+}
@@ -0,0 +1,149 @@
+package container_test
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	q := container.NewPriorityQueue(cmp.Compare[int])
+
+	var want []int
+	handles := map[*container.PriorityQueueHandle[int]]struct{}{}
+	for range 1000 {
+		v := rng.IntN(100)
+		handles[q.Push(v)] = struct{}{}
+		want = append(want, v)
+	}
+
+	// Update and remove some of the values.
+	n := 0
+	for h := range handles {
+		old := h.Value()
+		i := slices.Index(want, old)
+
+		if n%2 == 0 {
+			v := rng.IntN(100)
+			require.True(t, q.Update(h, v))
+
+			want[i] = v
+		} else {
+			v, ok := q.Remove(h)
+			require.True(t, ok)
+			require.Equal(t, old, v)
+
+			want = slices.Delete(want, i, i+1)
+		}
+
+		n++
+		if n == 100 {
+			break
+		}
+	}
+
+	slices.Sort(want)
+	require.Equal(t, len(want), q.Len())
+
+	got := slices.Collect(q.Drain())
+	assert.Equal(t, want, got)
+	assert.Zero(t, q.Len())
+}
+
+func TestPriorityQueue_handle(t *testing.T) {
+	t.Parallel()
+
+	q := container.NewPriorityQueue(cmp.Compare[int])
+	other := container.NewPriorityQueue(cmp.Compare[int])
+
+	h := q.Push(1)
+	q.Push(2)
+
+	assert.False(t, other.Update(h, 0))
+
+	_, ok := other.Remove(h)
+	assert.False(t, ok)
+
+	require.True(t, q.Update(h, 3))
+
+	v, ok := q.Peek()
+	require.True(t, ok)
+
+	assert.Equal(t, 2, v)
+
+	v, ok = q.Remove(h)
+	require.True(t, ok)
+
+	assert.Equal(t, 3, v)
+
+	// A removed handle doesn't belong to any queue.
+	_, ok = q.Remove(h)
+	assert.False(t, ok)
+	assert.False(t, q.Update(h, 0))
+
+	_, ok = q.Remove(nil)
+	assert.False(t, ok)
+	assert.False(t, q.Update(nil, 0))
+
+	var nilQueue *container.PriorityQueue[int]
+	_, ok = nilQueue.Remove(h)
+	assert.False(t, ok)
+	assert.False(t, nilQueue.Update(nil, 0))
+
+	q.Clear()
+	assert.Zero(t, q.Len())
+
+	_, ok = q.Pop()
+	assert.False(t, ok)
+
+	_, ok = q.Peek()
+	assert.False(t, ok)
+}
+
+func TestPriorityQueue_Drain(t *testing.T) {
+	t.Parallel()
+
+	q := container.NewPriorityQueue(cmp.Compare[int])
+	for _, v := range []int{5, 1, 4, 2, 3} {
+		q.Push(v)
+	}
+
+	var got []int
+	for v := range q.Drain() {
+		got = append(got, v)
+		if v == 2 {
+			break
+		}
+	}
+
+	assert.Equal(t, []int{1, 2}, got)
+	assert.Equal(t, []int{3, 4, 5}, slices.Collect(q.Drain()))
+}
+
+func BenchmarkPriorityQueue_Push(b *testing.B) {
+	q := container.NewPriorityQueue(cmp.Compare[int])
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	b.ReportAllocs()
+	for b.Loop() {
+		q.Push(rng.Int())
+		if q.Len() > 1000 {
+			_, _ = q.Pop()
+		}
+	}
+
+	// Most recent results:
+	//	goos: linux
+	//	goarch: amd64
+	//	pkg: github.com/AdguardTeam/golibs/container
+	//	cpu: Intel(R) Xeon(R) Processor
+	//	BenchmarkPriorityQueue_Push 	 4325650	       282.1 ns/op	      24 B/op	       1 allocs/op
+}
@@ -0,0 +1,249 @@
+package container
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/AdguardTeam/golibs/errors"
+)
+
+// Interval is a half-open interval of values, [Start, End).  An interval with
+// End less than or equal to Start is empty.
+//
+// Since End is exclusive, an interval cannot contain the maximum value of T.
+// Use [ClosedInterval] and [ClosedRangeSet] for such values.
+type Interval[T cmp.Ordered] struct {
+	// Start is the first value of the interval.
+	Start T
+
+	// End is the value right after the last value of the interval.
+	End T
+}
+
+// Contains returns true if v is in iv.
+func (iv Interval[T]) Contains(v T) (ok bool) {
+	return iv.Start <= v && v < iv.End
+}
+
+// IsEmpty returns true if iv contains no values.
+func (iv Interval[T]) IsEmpty() (ok bool) {
+	return iv.End <= iv.Start
+}
+
+// type check
+var _ fmt.Stringer = Interval[int]{}
+
+// String implements the [fmt.Stringer] interface for Interval.
+func (iv Interval[T]) String() (s string) {
+	return fmt.Sprintf("[%v, %v)", iv.Start, iv.End)
+}
+
+// halfOpenOps are the [intervalOps] for half-open intervals.
+type halfOpenOps[T cmp.Ordered] struct{}
+
+// type check
+var _ intervalOps[int, Interval[int]] = halfOpenOps[int]{}
+
+// bounds implements the [intervalOps] interface for halfOpenOps.
+func (halfOpenOps[T]) bounds(iv Interval[T]) (lo, hi T) { return iv.Start, iv.End }
+
+// interval implements the [intervalOps] interface for halfOpenOps.
+func (halfOpenOps[T]) interval(lo, hi T) (iv Interval[T]) {
+	return Interval[T]{Start: lo, End: hi}
+}
+
+// compare implements the [intervalOps] interface for halfOpenOps.
+func (halfOpenOps[T]) compare(a, b T) (res int) { return cmp.Compare(a, b) }
+
+// isEmpty implements the [intervalOps] interface for halfOpenOps.
+func (halfOpenOps[T]) isEmpty(iv Interval[T]) (ok bool) { return iv.IsEmpty() }
+
+// endsBefore implements the [intervalOps] interface for halfOpenOps.
+func (halfOpenOps[T]) endsBefore(iv Interval[T], v T) (ok bool) { return iv.End <= v }
+
+// isSeparate implements the [intervalOps] interface for halfOpenOps.
+func (halfOpenOps[T]) isSeparate(a, b Interval[T]) (ok bool) { return a.End < b.Start }
+
+// head implements the [intervalOps] interface for halfOpenOps.
+func (halfOpenOps[T]) head(a, b Interval[T]) (iv Interval[T]) {
+	return Interval[T]{Start: a.Start, End: b.Start}
+}
+
+// tail implements the [intervalOps] interface for halfOpenOps.
+func (halfOpenOps[T]) tail(a, b Interval[T]) (iv Interval[T]) {
+	return Interval[T]{Start: b.End, End: a.End}
+}
+
+// RangeSet is a set of values stored as a sorted list of disjoint intervals.
+// Overlapping and adjacent intervals are merged.  Lookups take O(log n) time,
+// where n is the number of intervals.  The zero value of RangeSet is an empty
+// set ready to use.
+type RangeSet[T cmp.Ordered] struct {
+	core intervalSet[T, Interval[T], halfOpenOps[T]]
+}
+
+// NewRangeSet returns a new *RangeSet containing the values from ivs.
+func NewRangeSet[T cmp.Ordered](ivs ...Interval[T]) (set *RangeSet[T]) {
+	set = &RangeSet[T]{}
+	for _, iv := range ivs {
+		set.Add(iv)
+	}
+
+	return set
+}
+
+// Add adds the values from iv to set, merging the intervals as necessary.  set
+// must not be nil.
+func (set *RangeSet[T]) Add(iv Interval[T]) {
+	set.core.add(iv)
+}
+
+// Delete deletes the values from iv from set, splitting the intervals as
+// necessary.  Calling Delete on a nil set has no effect.
+func (set *RangeSet[T]) Delete(iv Interval[T]) {
+	if set != nil {
+		set.core.delete(iv)
+	}
+}
+
+// Has returns true if v is in set.  Calling Has on a nil set returns false.
+func (set *RangeSet[T]) Has(v T) (ok bool) {
+	_, ok = set.Find(v)
+
+	return ok
+}
+
+// Find returns the interval of set that contains v.  Since the intervals are
+// merged, there is at most one such interval.  Calling Find on a nil set
+// returns false.
+func (set *RangeSet[T]) Find(v T) (iv Interval[T], ok bool) {
+	if set == nil {
+		return iv, false
+	}
+
+	// Don't use the shared implementation, since the calls through the
+	// interval operations make lookups several times slower.
+	ivs := set.core.ivs
+	i, _ := slices.BinarySearchFunc(ivs, v, func(other Interval[T], v T) (res int) {
+		if other.End <= v {
+			return -1
+		}
+
+		return 1
+	})
+	if i == len(ivs) || ivs[i].Start > v {
+		return iv, false
+	}
+
+	return ivs[i], true
+}
+
+// HasInterval returns true if all values from iv are in set.  An empty interval
+// is in any set.
+func (set *RangeSet[T]) HasInterval(iv Interval[T]) (ok bool) {
+	if set == nil {
+		return iv.IsEmpty()
+	}
+
+	return set.core.hasInterval(iv)
+}
+
+// Overlaps returns true if set has at least one value from iv.  Calling
+// Overlaps on a nil set returns false.
+func (set *RangeSet[T]) Overlaps(iv Interval[T]) (ok bool) {
+	return set != nil && set.core.overlaps(iv)
+}
+
+// Clear clears set in a way that retains the internal storage for later reuse
+// to reduce allocations.  Calling Clear on a nil set has no effect.
+func (set *RangeSet[T]) Clear() {
+	if set != nil {
+		set.core.clear()
+	}
+}
+
+// Clone returns a deep clone of set.  If set is nil, clone is nil.
+func (set *RangeSet[T]) Clone() (clone *RangeSet[T]) {
+	if set == nil {
+		return nil
+	}
+
+	return &RangeSet[T]{
+		core: set.core.clone(),
+	}
+}
+
+// Equal returns true if set is equal to other.  set and other may be nil;
+// Equal returns true if both are nil, but a nil *RangeSet is not equal to a
+// non-nil empty one.
+func (set *RangeSet[T]) Equal(other *RangeSet[T]) (ok bool) {
+	if set == nil || other == nil {
+		return set == other
+	}
+
+	return set.core.equal(&other.core)
+}
+
+// Len returns the number of the intervals in set, not the number of values.  A
+// nil set has a length of zero.
+func (set *RangeSet[T]) Len() (n int) {
+	return len(set.Intervals())
+}
+
+// Range calls f with each interval of set in the ascending order until f
+// returns false.  f must not modify set.  Calling Range on a nil set has no
+// effect.
+func (set *RangeSet[T]) Range(f func(iv Interval[T]) (cont bool)) {
+	for _, iv := range set.Intervals() {
+		if !f(iv) {
+			return
+		}
+	}
+}
+
+// type check
+var _ fmt.Stringer = (*RangeSet[int])(nil)
+
+// String implements the [fmt.Stringer] interface for *RangeSet.
+func (set *RangeSet[T]) String() (s string) {
+	return fmt.Sprintf("%v", set.Intervals())
+}
+
+// Intervals returns the underlying slice of intervals.  The slice must not be
+// modified.  Intervals returns nil if set is nil.
+func (set *RangeSet[T]) Intervals() (ivs []Interval[T]) {
+	if set == nil {
+		return nil
+	}
+
+	return set.core.ivs
+}
+
+// Union fills set with values belonging to either a or b.  set must not be nil.
+// Union returns an empty set if both a and b are nil.  If neither a nor b are
+// equal to set, then the function will rewrite the contents of set; otherwise,
+// it allocates new storage for set.
+func (set *RangeSet[T]) Union(a, b *RangeSet[T]) (res *RangeSet[T]) {
+	if set == nil {
+		panic(fmt.Errorf("set: %v", errors.ErrNoValue))
+	}
+
+	set.core.union(a.Intervals(), b.Intervals(), set == a || set == b)
+
+	return set
+}
+
+// Difference fills set with values belonging to a but not to b.  set must not
+// be nil.  Difference returns an empty set if a is nil.  If neither a nor b are
+// equal to set, then the function will rewrite the contents of set; otherwise,
+// it allocates new storage for set.
+func (set *RangeSet[T]) Difference(a, b *RangeSet[T]) (res *RangeSet[T]) {
+	if set == nil {
+		panic(fmt.Errorf("set: %v", errors.ErrNoValue))
+	}
+
+	set.core.difference(a.Intervals(), b.Intervals(), set == a || set == b)
+
+	return set
+}
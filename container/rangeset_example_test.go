@@ -0,0 +1,52 @@
+package container_test
+
+import (
+	"fmt"
+
+	"github.com/AdguardTeam/golibs/container"
+)
+
+func ExampleRangeSet() {
+	type portRange = container.Interval[uint32]
+
+	ports := container.NewRangeSet(
+		portRange{Start: 8000, End: 8080},
+		portRange{Start: 80, End: 81},
+		portRange{Start: 8080, End: 8100},
+	)
+
+	fmt.Println(ports)
+	fmt.Println(ports.Has(80), ports.Has(443), ports.Has(8080))
+
+	ports.Delete(portRange{Start: 8050, End: 8060})
+	fmt.Println(ports)
+
+	iv, ok := ports.Find(8070)
+	fmt.Println(iv, ok)
+
+	// Output:
+	// [[80, 81) [8000, 8100)]
+	// true false true
+	// [[80, 81) [8000, 8050) [8060, 8100)]
+	// [8060, 8100) true
+}
+
+func ExampleRangeSet_Union() {
+	a := container.NewRangeSet(container.Interval[int]{Start: 1, End: 5})
+	b := container.NewRangeSet(
+		container.Interval[int]{Start: 3, End: 8},
+		container.Interval[int]{Start: 10, End: 12},
+	)
+	set := container.NewRangeSet[int]()
+
+	fmt.Printf("a = %s, b = %s\n", a, b)
+	fmt.Printf("set = a ∪ b: %s\n", set.Union(a, b))
+	fmt.Printf("set = a \\ b: %s\n", set.Difference(a, b))
+	fmt.Printf("set = b \\ a: %s\n", set.Difference(b, a))
+
+	// Output:
+	// a = [[1, 5)], b = [[3, 8) [10, 12)]
+	// set = a ∪ b: [[1, 8) [10, 12)]
+	// set = a \ b: [[1, 3)]
+	// set = b \ a: [[5, 8) [10, 12)]
+}
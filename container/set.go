@@ -0,0 +1,110 @@
+package container
+
+// Set is the common interface of the set types in this package.  The methods
+// that read a set must be safe to call on a nil set, which must act as an empty
+// one.
+type Set[T comparable] interface {
+	// Add adds v to the set.
+	Add(v T)
+
+	// AddAll adds values to the set.
+	AddAll(values ...T)
+
+	// Clear deletes all values from the set.
+	Clear()
+
+	// Delete deletes v from the set.
+	Delete(v T)
+
+	// DeleteAll deletes values from the set.
+	DeleteAll(values ...T)
+
+	// Has returns true if v is in the set.
+	Has(v T) (ok bool)
+
+	// Len returns the number of values in the set.
+	Len() (n int)
+
+	// Range calls f with each value of the set until f returns false.
+	Range(f func(v T) (cont bool))
+}
+
+// type check
+var (
+	_ Set[int] = (*MapSet[int])(nil)
+	_ Set[int] = (*SortedSliceSet[int])(nil)
+)
+
+// SetAddSet adds all values of src to dst.  dst must not be nil.
+func SetAddSet[T comparable](dst, src Set[T]) {
+	src.Range(func(v T) (cont bool) {
+		dst.Add(v)
+
+		return true
+	})
+}
+
+// SetDeleteSet deletes all values of src from dst.
+func SetDeleteSet[T comparable](dst, src Set[T]) {
+	src.Range(func(v T) (cont bool) {
+		dst.Delete(v)
+
+		return true
+	})
+}
+
+// SetEqual returns true if a and b contain the same values, regardless of
+// their implementations.  Unlike the Equal methods, it considers nil sets
+// equal to empty ones.
+func SetEqual[T comparable](a, b Set[T]) (ok bool) {
+	return a.Len() == b.Len() && SetIsSubset(a, b)
+}
+
+// SetIntersects returns true if a and b have at least one common value.
+func SetIntersects[T comparable](a, b Set[T]) (ok bool) {
+	if a.Len() > b.Len() {
+		a, b = b, a
+	}
+
+	a.Range(func(v T) (cont bool) {
+		ok = b.Has(v)
+
+		return !ok
+	})
+
+	return ok
+}
+
+// SetIsSubset returns true if every value of a is also in b.  An empty set is a
+// subset of any set.
+func SetIsSubset[T comparable](a, b Set[T]) (ok bool) {
+	if a.Len() > b.Len() {
+		return false
+	}
+
+	ok = true
+	a.Range(func(v T) (cont bool) {
+		ok = b.Has(v)
+
+		return ok
+	})
+
+	return ok
+}
+
+// SetValues returns the values of set in the order of its Range method.  It
+// returns nil if set is empty.
+func SetValues[T comparable](set Set[T]) (values []T) {
+	if set.Len() == 0 {
+		return nil
+	}
+
+	values = make([]T, 0, set.Len())
+	set.Range(func(v T) (cont bool) {
+		values = append(values, v)
+
+		return true
+	})
+
+	return values
+}
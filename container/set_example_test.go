@@ -0,0 +1,31 @@
+package container_test
+
+import (
+	"fmt"
+
+	"github.com/AdguardTeam/golibs/container"
+)
+
+func ExampleSet() {
+	var m container.Set[int] = container.NewMapSet(1, 2)
+	var s container.Set[int] = container.NewSortedSliceSet(3, 2, 1)
+
+	fmt.Println(container.SetEqual(m, s))
+	fmt.Println(container.SetIsSubset(m, s))
+	fmt.Println(container.SetIsSubset(s, m))
+
+	container.SetAddSet(m, s)
+	fmt.Println(container.SetEqual(m, s))
+
+	container.SetDeleteSet(s, container.NewMapSet(1, 2))
+	fmt.Println(container.SetValues(s))
+	fmt.Println(container.SetIntersects(m, s))
+
+	// Output:
+	// false
+	// true
+	// false
+	// true
+	// [3]
+	// true
+}
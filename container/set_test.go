@@ -0,0 +1,160 @@
+package container_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/container"
+	"github.com/stretchr/testify/assert"
+)
+
+// setOp is a set operation for tests of both set implementations.
+type setOp struct {
+	mapSet    func(set, a, b *container.MapSet[int]) (res *container.MapSet[int])
+	sliceSet  func(set, a, b *container.SortedSliceSet[int]) (res *container.SortedSliceSet[int])
+	oracleHas func(inA, inB bool) (ok bool)
+	name      string
+}
+
+// testSetOps are the set operations checked by [TestSet_ops].
+var testSetOps = []setOp{{
+	mapSet:    (*container.MapSet[int]).Union,
+	sliceSet:  (*container.SortedSliceSet[int]).Union,
+	oracleHas: func(inA, inB bool) (ok bool) { return inA || inB },
+	name:      "union",
+}, {
+	mapSet:    (*container.MapSet[int]).Intersection,
+	sliceSet:  (*container.SortedSliceSet[int]).Intersection,
+	oracleHas: func(inA, inB bool) (ok bool) { return inA && inB },
+	name:      "intersection",
+}, {
+	mapSet:    (*container.MapSet[int]).Difference,
+	sliceSet:  (*container.SortedSliceSet[int]).Difference,
+	oracleHas: func(inA, inB bool) (ok bool) { return inA && !inB },
+	name:      "difference",
+}, {
+	mapSet:    (*container.MapSet[int]).SymmetricDifference,
+	sliceSet:  (*container.SortedSliceSet[int]).SymmetricDifference,
+	oracleHas: func(inA, inB bool) (ok bool) { return inA != inB },
+	name:      "symmetric_difference",
+}}
+
+// testSetMaxValue is the maximum value in the random sets in tests.
+const testSetMaxValue = 16
+
+// newRandInts returns a random slice of up to testSetMaxValue integers.
+func newRandInts(rng *rand.Rand) (values []int) {
+	n := rng.IntN(testSetMaxValue)
+	for range n {
+		values = append(values, rng.IntN(testSetMaxValue))
+	}
+
+	return values
+}
+
+// oracleValues returns the sorted values that op must leave in the result.
+func oracleValues(op setOp, a, b []int) (values []int) {
+	values = []int{}
+	for v := range testSetMaxValue {
+		if op.oracleHas(slices.Contains(a, v), slices.Contains(b, v)) {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// assertSetOp checks op with the receiver being a new set, a, and b.
+func assertSetOp(t *testing.T, op setOp, aVals, bVals []int) {
+	t.Helper()
+
+	want := oracleValues(op, aVals, bVals)
+
+	// Receivers: 0 is a new set, 1 is a, and 2 is b.
+	for recv := range 3 {
+		ma, mb := container.NewMapSet(aVals...), container.NewMapSet(bVals...)
+
+		// NewSortedSliceSet reuses and modifies the slice, so clone it.
+		sa := container.NewSortedSliceSet(slices.Clone(aVals)...)
+		sb := container.NewSortedSliceSet(slices.Clone(bVals)...)
+
+		mset := []*container.MapSet[int]{container.NewMapSet[int](), ma, mb}[recv]
+		sset := []*container.SortedSliceSet[int]{container.NewSortedSliceSet[int](), sa, sb}[recv]
+
+		mres := op.mapSet(mset, ma, mb)
+		sres := op.sliceSet(sset, sa, sb)
+
+		assert.ElementsMatch(t, want, mres.Values(), "recv %d", recv)
+		assert.ElementsMatch(t, want, sres.Values(), "recv %d", recv)
+		assert.True(t, slices.IsSorted(sres.Values()), "recv %d", recv)
+		assert.True(t, container.SetEqual[int](mres, sres), "recv %d", recv)
+	}
+}
+
+func TestSet_ops(t *testing.T) {
+	t.Parallel()
+
+	for _, op := range testSetOps {
+		t.Run(op.name, func(t *testing.T) {
+			t.Parallel()
+
+			rng := rand.New(rand.NewPCG(1, 2))
+			for range 100 {
+				assertSetOp(t, op, newRandInts(rng), newRandInts(rng))
+			}
+
+			vals := newRandInts(rng)
+			assertSetOp(t, op, vals, vals)
+			assertSetOp(t, op, nil, vals)
+			assertSetOp(t, op, vals, nil)
+		})
+	}
+}
+
+func TestSet_relations(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 100 {
+		aVals, bVals := newRandInts(rng), newRandInts(rng)
+
+		wantSubset := !slices.ContainsFunc(aVals, func(v int) (ok bool) {
+			return !slices.Contains(bVals, v)
+		})
+		wantIntersects := slices.ContainsFunc(aVals, func(v int) (ok bool) {
+			return slices.Contains(bVals, v)
+		})
+
+		ma, mb := container.NewMapSet(aVals...), container.NewMapSet(bVals...)
+		sa := container.NewSortedSliceSet(slices.Clone(aVals)...)
+		sb := container.NewSortedSliceSet(slices.Clone(bVals)...)
+
+		assert.Equal(t, wantSubset, ma.IsSubset(mb))
+		assert.Equal(t, wantSubset, sa.IsSubset(sb))
+		assert.Equal(t, wantSubset, mb.IsSuperset(ma))
+		assert.Equal(t, wantSubset, sb.IsSuperset(sa))
+		assert.Equal(t, wantSubset, container.SetIsSubset[int](ma, sb))
+		assert.Equal(t, wantIntersects, container.SetIntersects[int](sa, mb))
+	}
+}
+
+func TestSet_nil(t *testing.T) {
+	t.Parallel()
+
+	var (
+		nilMap   *container.MapSet[int]
+		nilSlice *container.SortedSliceSet[int]
+	)
+
+	empty := container.NewMapSet[int]()
+	assert.True(t, container.SetEqual[int](nilMap, nilSlice))
+	assert.True(t, container.SetEqual[int](nilMap, empty))
+	assert.True(t, container.SetIsSubset[int](nilSlice, empty))
+	assert.False(t, container.SetIntersects[int](nilMap, nilSlice))
+	assert.Empty(t, container.SetValues[int](nilSlice))
+
+	nilMap.DeleteAll(1, 2)
+	nilSlice.DeleteAll(1, 2)
+	container.SetDeleteSet[int](nilMap, container.NewMapSet(1))
+}
@@ -2,6 +2,8 @@ package container
 
 import (
 	"cmp"
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"slices"
 
@@ -38,6 +40,13 @@ func (set *SortedSliceSet[T]) Add(v T) {
 	}
 }
 
+// AddAll adds values to set.  set must not be nil.
+func (set *SortedSliceSet[T]) AddAll(values ...T) {
+	set.elems = append(set.elems, values...)
+	slices.Sort(set.elems)
+	set.elems = slices.Compact(set.elems)
+}
+
 // Clear clears set in a way that retains the internal storage for later reuse
 // to reduce allocations.  Calling Clear on a nil set has no effect, just like a
 // clear on a nil slice doesn't.
@@ -71,6 +80,14 @@ func (set *SortedSliceSet[T]) Delete(v T) {
 	}
 }
 
+// DeleteAll deletes values from set.  Calling DeleteAll on a nil set has no
+// effect.
+func (set *SortedSliceSet[T]) DeleteAll(values ...T) {
+	for _, v := range values {
+		set.Delete(v)
+	}
+}
+
 // Equal returns true if set is equal to other.  set and other may be nil; Equal
 // returns true if both are nil, but a nil *SortedSliceSet is not equal to a
 // non-nil empty one.
@@ -324,3 +341,229 @@ func (set *SortedSliceSet[T]) Intersects(other *SortedSliceSet[T]) (ok bool) {
 
 	return false
 }
+
+// Difference fills set with values that belong to a but not to b.  set must
+// not be nil.  Difference returns an empty set if a is nil.  If neither a nor b
+// are equal to set, then the function will rewrite the contents of set.  If b
+// is equal to set, the function allocates new storage for set.
+func (set *SortedSliceSet[T]) Difference(a, b *SortedSliceSet[T]) (res *SortedSliceSet[T]) {
+	if set == nil {
+		panic(fmt.Errorf("set: %v", errors.ErrNoValue))
+	}
+
+	switch {
+	case a == nil, a == b:
+		set.Clear()
+	case set == a:
+		set.elems = slices.DeleteFunc(set.elems, b.Has)
+	case set == b:
+		set.elems = appendDifference(make([]T, 0, len(a.elems)), a.elems, b.elems)
+	default:
+		set.elems = appendDifference(set.elems[:0], a.elems, b.Values())
+	}
+
+	return set
+}
+
+// appendDifference appends the values of a that are not in b to dst and
+// returns the result.  a and b must be sorted.
+func appendDifference[T cmp.Ordered](dst, a, b []T) (res []T) {
+	aIdx, bIdx := 0, 0
+	for aIdx < len(a) && bIdx < len(b) {
+		if a[aIdx] < b[bIdx] {
+			dst = append(dst, a[aIdx])
+			aIdx++
+		} else if a[aIdx] > b[bIdx] {
+			bIdx++
+		} else {
+			aIdx++
+			bIdx++
+		}
+	}
+
+	return append(dst, a[aIdx:]...)
+}
+
+// SymmetricDifference fills set with values that belong to either a or b but
+// not to both of them.  set must not be nil.  SymmetricDifference returns an
+// empty set if both a and b are nil.  If neither a nor b are equal to set, then
+// the function will rewrite the contents of set.  If either a or b is equal to
+// set, the function allocates new storage for set.
+func (set *SortedSliceSet[T]) SymmetricDifference(
+	a *SortedSliceSet[T],
+	b *SortedSliceSet[T],
+) (res *SortedSliceSet[T]) {
+	if set == nil {
+		panic(fmt.Errorf("set: %v", errors.ErrNoValue))
+	}
+
+	if a == b {
+		set.Clear()
+
+		return set
+	}
+
+	aElems, bElems := a.Values(), b.Values()
+	if set == a || set == b {
+		dst := make([]T, 0, len(aElems)+len(bElems))
+		set.elems = appendSymmetricDifference(dst, aElems, bElems)
+	} else {
+		set.elems = appendSymmetricDifference(set.elems[:0], aElems, bElems)
+	}
+
+	return set
+}
+
+// appendSymmetricDifference appends the values that are in either a or b but
+// not in both of them to dst and returns the result.  a and b must be sorted.
+func appendSymmetricDifference[T cmp.Ordered](dst, a, b []T) (res []T) {
+	aIdx, bIdx := 0, 0
+	for aIdx < len(a) && bIdx < len(b) {
+		if a[aIdx] < b[bIdx] {
+			dst = append(dst, a[aIdx])
+			aIdx++
+		} else if a[aIdx] > b[bIdx] {
+			dst = append(dst, b[bIdx])
+			bIdx++
+		} else {
+			aIdx++
+			bIdx++
+		}
+	}
+
+	dst = append(dst, a[aIdx:]...)
+
+	return append(dst, b[bIdx:]...)
+}
+
+// IsSubset returns true if every value of set is also in other.  A nil set is
+// considered empty, and an empty set is a subset of any set.
+func (set *SortedSliceSet[T]) IsSubset(other *SortedSliceSet[T]) (ok bool) {
+	elems, otherElems := set.Values(), other.Values()
+	if len(elems) > len(otherElems) {
+		return false
+	}
+
+	otherIdx := 0
+	for _, v := range elems {
+		for otherIdx < len(otherElems) && otherElems[otherIdx] < v {
+			otherIdx++
+		}
+
+		if otherIdx == len(otherElems) || otherElems[otherIdx] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSuperset returns true if every value of other is also in set.  A nil set is
+// considered empty, and any set is a superset of an empty set.
+func (set *SortedSliceSet[T]) IsSuperset(other *SortedSliceSet[T]) (ok bool) {
+	return other.IsSubset(set)
+}
+
+// type check
+var _ json.Marshaler = SortedSliceSet[int]{}
+
+// MarshalJSON implements the [json.Marshaler] interface for SortedSliceSet.  It
+// has a value receiver, so that fields of type SortedSliceSet are encoded as
+// well as the ones of type *SortedSliceSet.  set is encoded as a sorted JSON
+// array.  An empty set is encoded as an empty array.
+func (set SortedSliceSet[T]) MarshalJSON() (b []byte, err error) {
+	return json.Marshal(set.nonNilElems())
+}
+
+// type check
+var _ json.Unmarshaler = (*SortedSliceSet[int])(nil)
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface for
+// *SortedSliceSet.  b must be a JSON array or null.  The values from b replace
+// the contents of set, and null leaves set unchanged.
+func (set *SortedSliceSet[T]) UnmarshalJSON(b []byte) (err error) {
+	var values []T
+	err = json.Unmarshal(b, &values)
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return err
+	}
+
+	set.replace(values)
+
+	return nil
+}
+
+// type check
+var _ encoding.TextMarshaler = SortedSliceSet[int]{}
+
+// MarshalText implements the [encoding.TextMarshaler] interface for
+// SortedSliceSet.  set is encoded as the sorted text encodings of its values
+// separated by commas.  T must either implement [encoding.TextMarshaler] or be
+// one of the predeclared string or number types, and the encodings of the
+// values must not contain commas.
+func (set SortedSliceSet[T]) MarshalText() (text []byte, err error) {
+	return marshalTextValues(set.nonNilElems())
+}
+
+// type check
+var _ encoding.TextUnmarshaler = (*SortedSliceSet[int])(nil)
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface for
+// *SortedSliceSet.  text must be in the format described in
+// [SortedSliceSet.MarshalText].  The values from text replace the contents of
+// set.  An empty text is decoded as an empty set.
+func (set *SortedSliceSet[T]) UnmarshalText(text []byte) (err error) {
+	values, err := unmarshalTextValues[T](text)
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return err
+	}
+
+	set.replace(values)
+
+	return nil
+}
+
+// MarshalYAML implements the Marshaler interface of the YAML libraries for
+// SortedSliceSet.  set is encoded as a sorted sequence.
+func (set SortedSliceSet[T]) MarshalYAML() (v any, err error) {
+	return set.nonNilElems(), nil
+}
+
+// UnmarshalYAML implements the function-based Unmarshaler interface of the YAML
+// libraries for *SortedSliceSet.  It works the same way as
+// [SortedSliceSet.UnmarshalJSON].
+func (set *SortedSliceSet[T]) UnmarshalYAML(unmarshal func(v any) (err error)) (err error) {
+	var values []T
+	err = unmarshal(&values)
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return err
+	}
+
+	set.replace(values)
+
+	return nil
+}
+
+// nonNilElems returns the elements of set or an empty slice if there are none,
+// so that an empty set is never encoded as null.
+func (set SortedSliceSet[T]) nonNilElems() (elems []T) {
+	if set.elems == nil {
+		return []T{}
+	}
+
+	return set.elems
+}
+
+// replace replaces the contents of set with values unless values is nil.  set
+// must not be nil.
+func (set *SortedSliceSet[T]) replace(values []T) {
+	if values == nil {
+		return
+	}
+
+	slices.Sort(values)
+	set.elems = slices.Compact(values)
+}
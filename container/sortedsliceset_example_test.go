@@ -1,6 +1,7 @@
 package container_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"slices"
 
@@ -255,3 +256,111 @@ func ExampleSortedSliceSet_nil() {
 	// panic after intersection: true
 	// panic after intersects: false
 }
+
+func ExampleSortedSliceSet_Difference() {
+	a := container.NewSortedSliceSet(1, 6, 10)
+	b := container.NewSortedSliceSet(3, 6, 12)
+	set := container.NewSortedSliceSet[int]()
+
+	fmt.Printf("a = %s, b = %s\n", a, b)
+	fmt.Printf("set = a \\ b:     %s\n", set.Difference(a, b))
+	fmt.Printf("set = nil \\ nil: %s\n", set.Difference(nil, nil))
+	fmt.Printf("set = nil \\ b:   %s\n", set.Difference(nil, b))
+	fmt.Printf("set = a \\ nil:   %s\n", set.Difference(a, nil))
+	fmt.Printf("a = a \\ b:       %s\n", a.Difference(a, b))
+
+	a = container.NewSortedSliceSet(1, 6, 10)
+	fmt.Printf("b = a \\ b:       %s\n", b.Difference(a, b))
+
+	// Output:
+	// a = [1 6 10], b = [3 6 12]
+	// set = a \ b:     [1 10]
+	// set = nil \ nil: []
+	// set = nil \ b:   []
+	// set = a \ nil:   [1 6 10]
+	// a = a \ b:       [1 10]
+	// b = a \ b:       [1 10]
+}
+
+func ExampleSortedSliceSet_SymmetricDifference() {
+	a := container.NewSortedSliceSet(1, 6, 10)
+	b := container.NewSortedSliceSet(3, 6, 12)
+	set := container.NewSortedSliceSet[int]()
+
+	fmt.Printf("a = %s, b = %s\n", a, b)
+	fmt.Printf("set = a △ b:     %s\n", set.SymmetricDifference(a, b))
+	fmt.Printf("set = nil △ nil: %s\n", set.SymmetricDifference(nil, nil))
+	fmt.Printf("set = nil △ b:   %s\n", set.SymmetricDifference(nil, b))
+	fmt.Printf("set = a △ nil:   %s\n", set.SymmetricDifference(a, nil))
+	fmt.Printf("a = a △ b:       %s\n", a.SymmetricDifference(a, b))
+
+	a = container.NewSortedSliceSet(1, 6, 10)
+	fmt.Printf("b = a △ b:       %s\n", b.SymmetricDifference(a, b))
+
+	// Output:
+	// a = [1 6 10], b = [3 6 12]
+	// set = a △ b:     [1 3 10 12]
+	// set = nil △ nil: []
+	// set = nil △ b:   [3 6 12]
+	// set = a △ nil:   [1 6 10]
+	// a = a △ b:       [1 3 10 12]
+	// b = a △ b:       [1 3 10 12]
+}
+
+func ExampleSortedSliceSet_IsSubset() {
+	a := container.NewSortedSliceSet(1, 6)
+	b := container.NewSortedSliceSet(1, 6, 12)
+	var nilSet *container.SortedSliceSet[int]
+
+	fmt.Printf("a = %s, b = %s\n", a, b)
+	fmt.Printf("a ⊆ b:     %t\n", a.IsSubset(b))
+	fmt.Printf("b ⊆ a:     %t\n", b.IsSubset(a))
+	fmt.Printf("b ⊇ a:     %t\n", b.IsSuperset(a))
+	fmt.Printf("nil ⊆ a:   %t\n", nilSet.IsSubset(a))
+	fmt.Printf("a ⊆ nil:   %t\n", a.IsSubset(nilSet))
+	fmt.Printf("nil ⊆ nil: %t\n", nilSet.IsSubset(nilSet))
+
+	// Output:
+	// a = [1 6], b = [1 6 12]
+	// a ⊆ b:     true
+	// b ⊆ a:     false
+	// b ⊇ a:     true
+	// nil ⊆ a:   true
+	// a ⊆ nil:   false
+	// nil ⊆ nil: true
+}
+
+func ExampleSortedSliceSet_AddAll() {
+	set := container.NewSortedSliceSet(1, 6)
+
+	set.AddAll(10, 3, 6)
+	fmt.Println(set)
+
+	set.DeleteAll(1, 10, 12)
+	fmt.Println(set)
+
+	// Output:
+	// [1 3 6 10]
+	// [3 6]
+}
+
+func ExampleSortedSliceSet_MarshalJSON() {
+	set := &container.SortedSliceSet[int]{}
+	err := json.Unmarshal([]byte(`[10, 1, 2, 1]`), set)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(set)
+
+	b, err := json.Marshal(set)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(string(b))
+
+	// Output:
+	// [1 2 10]
+	// [1,2,10]
+}
@@ -1,11 +1,14 @@
 package container_test
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/AdguardTeam/golibs/container"
+	"github.com/AdguardTeam/golibs/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -216,3 +219,116 @@ func BenchmarkSortedSliceSet_Has(b *testing.B) {
 	//	BenchmarkSortedSliceSet_Has/100000_strings
 	//	BenchmarkSortedSliceSet_Has/100000_strings-16     	14932011	        79.37 ns/op	       0 B/op	       0 allocs/op
 }
+
+func TestSortedSliceSet_encoding(t *testing.T) {
+	t.Parallel()
+
+	intSet := container.NewSortedSliceSet(10, 1, 2)
+	testutil.AssertMarshalJSON(t, `[1,2,10]`, intSet)
+
+	emptySet := container.NewSortedSliceSet[int]()
+	testutil.AssertMarshalJSON(t, `[]`, emptySet)
+	testutil.AssertUnmarshalJSON(t, `[10,1,2,1]`, container.NewSortedSliceSet(1, 2, 10))
+
+	set := container.NewSortedSliceSet(1, 2)
+	err := json.Unmarshal([]byte(`null`), set)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2}, set.Values())
+
+	err = json.Unmarshal([]byte(`[3]`), set)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{3}, set.Values())
+
+	err = json.Unmarshal([]byte(`["bad"]`), set)
+	require.Error(t, err)
+
+	// An invalid document must not change the set.
+	assert.Equal(t, []int{3}, set.Values())
+}
+
+func TestSortedSliceSet_text(t *testing.T) {
+	t.Parallel()
+
+	// NOTE:  Don't use [testutil.AssertMarshalText], since package json prefers
+	// the JSON methods.
+	testCases := []struct {
+		set  encoding.TextMarshaler
+		name string
+		want string
+	}{{
+		set:  container.NewSortedSliceSet("c", "a", "b"),
+		name: "strings",
+		want: "a,b,c",
+	}, {
+		set:  container.NewSortedSliceSet[string](),
+		name: "empty",
+		want: "",
+	}, {
+		set:  container.NewSortedSliceSet(10, 1, 2),
+		name: "ints",
+		want: "1,2,10",
+	}, {
+		set:  container.NewSortedSliceSet(0.5, -1.25),
+		name: "floats",
+		want: "-1.25,0.5",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			text, err := tc.set.MarshalText()
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.want, string(text))
+		})
+	}
+
+	intSet := container.NewSortedSliceSet(3)
+	require.NoError(t, intSet.UnmarshalText([]byte("10,1,2,1")))
+
+	assert.Equal(t, []int{1, 2, 10}, intSet.Values())
+
+	require.NoError(t, intSet.UnmarshalText([]byte("")))
+
+	assert.Empty(t, intSet.Values())
+
+	_, err := container.NewSortedSliceSet("a,b").MarshalText()
+	testutil.AssertErrorMsg(t, `at index 0: value "a,b": contains separator ','`, err)
+
+	set := container.NewSortedSliceSet[uint8](1)
+	err = set.UnmarshalText([]byte("2,256"))
+	testutil.AssertErrorMsg(
+		t,
+		`at index 1: json: cannot unmarshal number 256 into Go value of type uint8`,
+		err,
+	)
+
+	// An invalid text must not change the set.
+	assert.Equal(t, []uint8{1}, set.Values())
+
+	// Named types must implement [encoding.TextMarshaler].
+	type port uint16
+
+	_, err = container.NewSortedSliceSet[port](80).MarshalText()
+	testutil.AssertErrorMsg(t, `at index 0: type container_test.port: no text encoding`, err)
+}
+
+func TestSortedSliceSet_yaml(t *testing.T) {
+	t.Parallel()
+
+	v, err := container.NewSortedSliceSet(10, 1, 2).MarshalYAML()
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2, 10}, v)
+
+	set := &container.SortedSliceSet[int]{}
+	err = set.UnmarshalYAML(func(v any) (err error) {
+		return json.Unmarshal([]byte(`[2,1,2]`), v)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2}, set.Values())
+}
@@ -0,0 +1,97 @@
+package container
+
+import "sync"
+
+// SyncRingBuffer is a [RingBuffer] that is safe for concurrent use.  The
+// methods that read several elements work on a snapshot of the buffer, so they
+// never see a partially updated buffer.
+type SyncRingBuffer[T any] struct {
+	// mu protects rb.
+	mu *sync.RWMutex
+	rb *RingBuffer[T]
+}
+
+// NewSyncRingBuffer initializes a new concurrent ring buffer with the given
+// size.
+func NewSyncRingBuffer[T any](size uint) (rb *SyncRingBuffer[T]) {
+	return &SyncRingBuffer[T]{
+		mu: &sync.RWMutex{},
+		rb: NewRingBuffer[T](size),
+	}
+}
+
+// Push adds an element to the buffer, overwriting the oldest element if the
+// buffer is full.
+func (rb *SyncRingBuffer[T]) Push(e T) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	rb.rb.Push(e)
+}
+
+// Current returns the element at the current position.  It returns zero value
+// of T if rb is empty.
+func (rb *SyncRingBuffer[T]) Current() (e T) {
+	rb.mu.RLock()
+	defer rb.mu.RUnlock()
+
+	return rb.rb.Current()
+}
+
+// Len returns a length of the buffer.
+func (rb *SyncRingBuffer[T]) Len() (l uint) {
+	rb.mu.RLock()
+	defer rb.mu.RUnlock()
+
+	return rb.rb.Len()
+}
+
+// Clear clears the buffer.
+func (rb *SyncRingBuffer[T]) Clear() {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	rb.rb.Clear()
+}
+
+// Snapshot returns a copy of the elements of the buffer from the oldest to the
+// newest one.  It returns nil if the buffer is empty.
+func (rb *SyncRingBuffer[T]) Snapshot() (elems []T) {
+	rb.mu.RLock()
+	defer rb.mu.RUnlock()
+
+	l := rb.rb.Len()
+	if l == 0 {
+		return nil
+	}
+
+	elems = make([]T, 0, l)
+	rb.rb.Range(func(e T) (cont bool) {
+		elems = append(elems, e)
+
+		return true
+	})
+
+	return elems
+}
+
+// Range calls f for each element of a snapshot of the buffer from the oldest
+// to the newest one until f returns false.  f may call the methods of rb.
+func (rb *SyncRingBuffer[T]) Range(f func(T) (cont bool)) {
+	for _, e := range rb.Snapshot() {
+		if !f(e) {
+			return
+		}
+	}
+}
+
+// ReverseRange is like [SyncRingBuffer.Range] but from the newest element to
+// the oldest one.
+func (rb *SyncRingBuffer[T]) ReverseRange(f func(T) (cont bool)) {
+	elems := rb.Snapshot()
+	for i := len(elems) - 1; i >= 0; i-- {
+		if !f(elems[i]) {
+			return
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package container_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncRingBuffer(t *testing.T) {
+	t.Parallel()
+
+	const size = 5
+
+	rb := container.NewSyncRingBuffer[int](size)
+	assert.Nil(t, rb.Snapshot())
+
+	for i := range 7 {
+		rb.Push(i)
+	}
+
+	assert.Equal(t, []int{2, 3, 4, 5, 6}, rb.Snapshot())
+	assert.Equal(t, uint(size), rb.Len())
+	assert.Equal(t, 2, rb.Current())
+
+	var reversed []int
+	for e := range rb.ReverseRange {
+		reversed = append(reversed, e)
+	}
+
+	assert.Equal(t, []int{6, 5, 4, 3, 2}, reversed)
+
+	// f may call the methods of the buffer.
+	for e := range rb.Range {
+		rb.Push(e)
+	}
+
+	assert.Equal(t, []int{2, 3, 4, 5, 6}, rb.Snapshot())
+
+	rb.Clear()
+	assert.Zero(t, rb.Len())
+}
+
+func TestSyncRingBuffer_concurrent(t *testing.T) {
+	t.Parallel()
+
+	const (
+		size      = 10
+		pushNum   = 1_000
+		readerNum = 4
+	)
+
+	rb := container.NewSyncRingBuffer[int](size)
+
+	wg := &sync.WaitGroup{}
+	wg.Go(func() {
+		for i := range pushNum {
+			rb.Push(i)
+		}
+	})
+
+	for range readerNum {
+		wg.Go(func() {
+			for range pushNum {
+				elems := rb.Snapshot()
+
+				// The elements are always consecutive.
+				for i := 1; i < len(elems); i++ {
+					assert.Equal(t, elems[i-1]+1, elems[i])
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, pushNum-1, rb.Snapshot()[size-1])
+}
@@ -3,6 +3,7 @@ package contextutil
 
 import (
 	"context"
+	"slices"
 	"time"
 )
 
@@ -52,3 +53,96 @@ func (c *TimeoutConstructor) New(
 ) (ctx context.Context, cancel context.CancelFunc) {
 	return context.WithTimeout(parent, c.timeout)
 }
+
+// DetachedConstructor is an implementation of the [Constructor] interface that
+// returns a context that is not canceled when parent is canceled, but still has
+// the deadline of parent, if any, and all its values, such as the logger.  It
+// is useful for background work triggered by requests.  Use it together with
+// [DeadlineCapConstructor] in a [ChainConstructor] to also limit the duration of
+// such work.
+type DetachedConstructor struct{}
+
+// type check
+var _ Constructor = DetachedConstructor{}
+
+// New implements the [Constructor] interface for DetachedConstructor.  If the
+// deadline of parent has already passed, ctx is already done.
+func (DetachedConstructor) New(
+	parent context.Context,
+) (ctx context.Context, cancel context.CancelFunc) {
+	ctx = context.WithoutCancel(parent)
+	if dl, ok := parent.Deadline(); ok {
+		return context.WithDeadline(ctx, dl)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// DeadlineCapConstructor is an implementation of the [Constructor] interface
+// that returns a context with the deadline of the parent capped at the given
+// timeout, that is, the earliest of the deadline of the parent, if any, and the
+// current time plus the timeout.  The deadline of the parent is never extended.
+type DeadlineCapConstructor struct {
+	timeout time.Duration
+}
+
+// NewDeadlineCapConstructor returns a new properly initialized
+// *DeadlineCapConstructor.
+func NewDeadlineCapConstructor(timeout time.Duration) (c *DeadlineCapConstructor) {
+	return &DeadlineCapConstructor{
+		timeout: timeout,
+	}
+}
+
+// type check
+var _ Constructor = (*DeadlineCapConstructor)(nil)
+
+// New implements the [Constructor] interface for *DeadlineCapConstructor.  If
+// parent has no deadline, the returned context expires after the timeout.
+func (c *DeadlineCapConstructor) New(
+	parent context.Context,
+) (ctx context.Context, cancel context.CancelFunc) {
+	// NOTE:  The deadline of the new context is never later than the one of
+	// parent, see [context.WithDeadline].
+	return context.WithTimeout(parent, c.timeout)
+}
+
+// ChainConstructor is an implementation of the [Constructor] interface that
+// applies several constructors in order, each one to the context returned by
+// the previous one.
+type ChainConstructor struct {
+	constructors []Constructor
+}
+
+// NewChainConstructor returns a new properly initialized *ChainConstructor.
+// constructors must not contain nil values.
+func NewChainConstructor(constructors ...Constructor) (c *ChainConstructor) {
+	return &ChainConstructor{
+		constructors: constructors,
+	}
+}
+
+// type check
+var _ Constructor = (*ChainConstructor)(nil)
+
+// New implements the [Constructor] interface for *ChainConstructor.  cancel
+// calls the cancellation functions of all constructors in the reverse order.
+// If c has no constructors, it returns parent and an empty cancel.
+func (c *ChainConstructor) New(
+	parent context.Context,
+) (ctx context.Context, cancel context.CancelFunc) {
+	cancels := make([]context.CancelFunc, 0, len(c.constructors))
+
+	ctx = parent
+	for _, cons := range c.constructors {
+		var consCancel context.CancelFunc
+		ctx, consCancel = cons.New(ctx)
+		cancels = append(cancels, consCancel)
+	}
+
+	return ctx, func() {
+		for _, f := range slices.Backward(cancels) {
+			f()
+		}
+	}
+}
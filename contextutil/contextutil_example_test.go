@@ -0,0 +1,33 @@
+package contextutil_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AdguardTeam/golibs/contextutil"
+)
+
+func ExampleChainConstructor() {
+	type requestIDKey struct{}
+
+	// Background work triggered by a request must not be canceled when the
+	// request is finished, but must still have a timeout.
+	c := contextutil.NewChainConstructor(
+		contextutil.DetachedConstructor{},
+		contextutil.NewTimeoutConstructor(1*time.Minute),
+	)
+
+	reqCtx, reqCancel := context.WithCancel(context.Background())
+	reqCtx = context.WithValue(reqCtx, requestIDKey{}, "req-1234")
+	reqCancel()
+
+	ctx, cancel := c.New(reqCtx)
+	defer cancel()
+
+	_, hasDeadline := ctx.Deadline()
+	fmt.Println(ctx.Err(), ctx.Value(requestIDKey{}), hasDeadline)
+
+	// Output:
+	// <nil> req-1234 true
+}
@@ -7,8 +7,12 @@ import (
 
 	"github.com/AdguardTeam/golibs/contextutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// testKey is the context key type for tests.
+type testKey struct{}
+
 func TestTimeoutConstructor(t *testing.T) {
 	const timeout = 1 * time.Minute
 
@@ -22,3 +26,206 @@ func TestTimeoutConstructor(t *testing.T) {
 	d := time.Until(dl)
 	assert.InDelta(t, timeout, d, float64(1*time.Second))
 }
+
+func TestDetachedConstructor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no_deadline", func(t *testing.T) {
+		t.Parallel()
+
+		parent, parentCancel := context.WithCancel(context.Background())
+		parent = context.WithValue(parent, testKey{}, "value")
+		parentCancel()
+
+		ctx, cancel := contextutil.DetachedConstructor{}.New(parent)
+
+		require.NoError(t, ctx.Err())
+		assert.Equal(t, "value", ctx.Value(testKey{}))
+
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+
+		cancel()
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		t.Parallel()
+
+		parent, parentCancel := context.WithTimeout(context.Background(), 1*time.Minute)
+		parentCancel()
+
+		ctx, cancel := contextutil.DetachedConstructor{}.New(parent)
+		defer cancel()
+
+		require.NoError(t, ctx.Err())
+
+		parentDl, _ := parent.Deadline()
+		dl, ok := ctx.Deadline()
+		require.True(t, ok)
+
+		assert.Equal(t, parentDl, dl)
+	})
+}
+
+func TestDeadlineCapConstructor(t *testing.T) {
+	t.Parallel()
+
+	const timeout = 1 * time.Minute
+
+	c := contextutil.NewDeadlineCapConstructor(timeout)
+
+	t.Run("no_deadline", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := c.New(context.Background())
+		defer cancel()
+
+		dl, ok := ctx.Deadline()
+		require.True(t, ok)
+
+		assert.InDelta(t, timeout, time.Until(dl), float64(1*time.Second))
+	})
+
+	t.Run("capped", func(t *testing.T) {
+		t.Parallel()
+
+		parent, parentCancel := context.WithTimeout(context.Background(), 2*timeout)
+		defer parentCancel()
+
+		ctx, cancel := c.New(parent)
+		defer cancel()
+
+		dl, ok := ctx.Deadline()
+		require.True(t, ok)
+
+		assert.InDelta(t, timeout, time.Until(dl), float64(1*time.Second))
+	})
+
+	t.Run("not_extended", func(t *testing.T) {
+		t.Parallel()
+
+		parent, parentCancel := context.WithTimeout(context.Background(), timeout/2)
+		defer parentCancel()
+
+		ctx, cancel := c.New(parent)
+		defer cancel()
+
+		parentDl, _ := parent.Deadline()
+		dl, ok := ctx.Deadline()
+		require.True(t, ok)
+
+		assert.Equal(t, parentDl, dl)
+	})
+}
+
+func TestChainConstructor(t *testing.T) {
+	t.Parallel()
+
+	const timeout = 1 * time.Minute
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		parent := context.Background()
+		ctx, cancel := contextutil.NewChainConstructor().New(parent)
+		cancel()
+
+		assert.Equal(t, parent, ctx)
+	})
+
+	t.Run("detached_timeout", func(t *testing.T) {
+		t.Parallel()
+
+		c := contextutil.NewChainConstructor(
+			contextutil.DetachedConstructor{},
+			contextutil.NewTimeoutConstructor(timeout),
+		)
+
+		parent, parentCancel := context.WithCancel(context.Background())
+		parent = context.WithValue(parent, testKey{}, "value")
+		parentCancel()
+
+		ctx, cancel := c.New(parent)
+
+		require.NoError(t, ctx.Err())
+		assert.Equal(t, "value", ctx.Value(testKey{}))
+
+		dl, ok := ctx.Deadline()
+		require.True(t, ok)
+
+		assert.InDelta(t, timeout, time.Until(dl), float64(1*time.Second))
+
+		cancel()
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+
+	t.Run("detached_capped", func(t *testing.T) {
+		t.Parallel()
+
+		c := contextutil.NewChainConstructor(
+			contextutil.DetachedConstructor{},
+			contextutil.NewDeadlineCapConstructor(timeout),
+		)
+
+		parent, parentCancel := context.WithTimeout(context.Background(), 2*timeout)
+		parentCancel()
+
+		ctx, cancel := c.New(parent)
+		defer cancel()
+
+		require.NoError(t, ctx.Err())
+
+		dl, ok := ctx.Deadline()
+		require.True(t, ok)
+
+		assert.InDelta(t, timeout, time.Until(dl), float64(1*time.Second))
+
+		parent, parentCancel = context.WithTimeout(context.Background(), timeout/2)
+		parentCancel()
+
+		ctx, cancel = c.New(parent)
+		defer cancel()
+
+		parentDl, _ := parent.Deadline()
+		dl, ok = ctx.Deadline()
+		require.True(t, ok)
+
+		assert.Equal(t, parentDl, dl)
+	})
+
+	t.Run("cancel_order", func(t *testing.T) {
+		t.Parallel()
+
+		var calls []int
+		newCons := func(i int) (c contextutil.Constructor) {
+			return &fakeConstructor{
+				onNew: func(parent context.Context) (context.Context, context.CancelFunc) {
+					return parent, func() { calls = append(calls, i) }
+				},
+			}
+		}
+
+		_, cancel := contextutil.NewChainConstructor(newCons(1), newCons(2)).New(
+			context.Background(),
+		)
+		cancel()
+
+		assert.Equal(t, []int{2, 1}, calls)
+	})
+}
+
+// fakeConstructor is a [contextutil.Constructor] for tests.
+type fakeConstructor struct {
+	onNew func(parent context.Context) (ctx context.Context, cancel context.CancelFunc)
+}
+
+// type check
+var _ contextutil.Constructor = (*fakeConstructor)(nil)
+
+// New implements the [contextutil.Constructor] interface for *fakeConstructor.
+func (c *fakeConstructor) New(
+	parent context.Context,
+) (ctx context.Context, cancel context.CancelFunc) {
+	return c.onNew(parent)
+}
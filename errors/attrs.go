@@ -0,0 +1,76 @@
+package errors
+
+import (
+	"log/slog"
+)
+
+// WithAttrs returns an error that wraps err and contains the structured
+// attributes attrs, such as the host or the request ID related to the error.
+// This allows keeping the error message short and constant while still
+// providing the context for logging.  If err is nil, withAttrs is nil.  If attrs
+// are empty, err is returned as is.
+//
+// The attributes can be retrieved with [Attrs].
+func WithAttrs(err error, attrs ...slog.Attr) (withAttrs error) {
+	if err == nil || len(attrs) == 0 {
+		return err
+	}
+
+	return &attrsError{
+		err:   err,
+		attrs: attrs,
+	}
+}
+
+// Attrs returns the attributes added with [WithAttrs] to all errors in the tree
+// of err, including both errors of a [*Pair], with the outermost attributes
+// first.  It returns nil if there are none.
+func Attrs(err error) (attrs []slog.Attr) {
+	return appendAttrs(nil, err)
+}
+
+// appendAttrs appends the attributes of all errors in the tree of err to attrs
+// and returns the result.
+func appendAttrs(attrs []slog.Attr, err error) (res []slog.Attr) {
+	switch err := err.(type) {
+	case nil:
+		return attrs
+	case *attrsError:
+		return appendAttrs(append(attrs, err.attrs...), err.err)
+	case *Pair:
+		return appendAttrs(appendAttrs(attrs, err.Returned), err.Deferred)
+	case WrapperSlice:
+		for _, e := range err.Unwrap() {
+			attrs = appendAttrs(attrs, e)
+		}
+
+		return attrs
+	case Wrapper:
+		return appendAttrs(attrs, err.Unwrap())
+	default:
+		return attrs
+	}
+}
+
+// attrsError is an error with structured attributes.
+type attrsError struct {
+	err   error
+	attrs []slog.Attr
+}
+
+// type check
+var _ error = (*attrsError)(nil)
+
+// Error implements the error interface for *attrsError.  It returns the message
+// of the underlying error.
+func (err *attrsError) Error() (msg string) {
+	return err.err.Error()
+}
+
+// type check
+var _ Wrapper = (*attrsError)(nil)
+
+// Unwrap implements the [Wrapper] interface for *attrsError.
+func (err *attrsError) Unwrap() (unwrapped error) {
+	return err.err
+}
@@ -0,0 +1,37 @@
+package errors_test
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/AdguardTeam/golibs/errors"
+)
+
+func ExampleWithAttrs() {
+	const errNotFound errors.Error = "not found"
+
+	err := errors.WithAttrs(errNotFound, slog.String("host", "example.com"))
+	err = errors.Annotate(err, "resolving: %w")
+	err = errors.WithAttrs(err, slog.Int("port", 53))
+
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, errNotFound))
+	fmt.Println(errors.Attrs(err))
+
+	joined := errors.Join(
+		errors.WithDeferred(err, errors.WithAttrs(errNotFound, slog.Bool("deferred", true))),
+		errors.WithAttrs(errNotFound, slog.String("request_id", "1234")),
+	)
+	fmt.Println(errors.Attrs(joined))
+
+	fmt.Println(errors.Attrs(errNotFound))
+	fmt.Println(errors.WithAttrs(errNotFound) == errNotFound)
+
+	// Output:
+	// resolving: not found
+	// true
+	// [port=53 host=example.com]
+	// [port=53 host=example.com deferred=true request_id=1234]
+	// []
+	// true
+}
@@ -0,0 +1,143 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Collector accumulates errors, for example the errors of validating a large
+// configuration, while keeping the resulting error reasonably small.  It only
+// keeps up to a limited number of errors and counts the dropped ones.  Errors
+// with the same messages are only kept once.  It must be initialized with
+// [NewCollector].  It is not safe for concurrent use.
+type Collector struct {
+	// indexes are the indexes of the kept errors by their messages.
+	indexes map[string]int
+
+	// errs are the kept errors in the order of adding.
+	errs []error
+
+	// counts are the numbers of times each kept error has been added.
+	counts []uint
+
+	// limit is the maximum number of kept errors.  If it is zero, the number
+	// is not limited.
+	limit uint
+
+	// dropped is the number of errors dropped due to limit.
+	dropped uint
+}
+
+// NewCollector returns a new properly initialized *Collector that keeps up to
+// limit errors with distinct messages.  If limit is zero, the number of errors
+// is not limited.
+func NewCollector(limit uint) (c *Collector) {
+	return &Collector{
+		indexes: map[string]int{},
+		limit:   limit,
+	}
+}
+
+// Add adds err to c.  If err is nil, it does nothing.  If c already contains an
+// error with the same message, err is only counted.  If c already contains the
+// maximum number of errors, err is dropped.
+func (c *Collector) Add(err error) {
+	if err == nil {
+		return
+	}
+
+	msg := err.Error()
+	if i, ok := c.indexes[msg]; ok {
+		c.counts[i]++
+
+		return
+	}
+
+	if c.limit > 0 && uint(len(c.errs)) >= c.limit {
+		c.dropped++
+
+		return
+	}
+
+	c.indexes[msg] = len(c.errs)
+	c.errs = append(c.errs, err)
+	c.counts = append(c.counts, 1)
+}
+
+// Len returns the number of errors kept in c.
+func (c *Collector) Len() (n int) {
+	return len(c.errs)
+}
+
+// Dropped returns the number of errors dropped, because c already contained
+// the maximum number of errors.
+func (c *Collector) Dropped() (n uint) {
+	return c.dropped
+}
+
+// Err returns the errors collected by c as a single error.  If no errors have
+// been added, err is nil.  If a single error has been added once, err is that
+// error.  Otherwise, err formats as the total number of errors followed by a
+// numbered list of the messages of the kept errors in the order of adding, and
+// the errors can be inspected with [Is] and [As].  For example:
+//
+//	4 errors:
+//	1. first error (2 times)
+//	2. second error
+//	and 1 more error
+func (c *Collector) Err() (err error) {
+	switch {
+	case len(c.errs) == 0:
+		return nil
+	case len(c.errs) == 1 && c.counts[0] == 1 && c.dropped == 0:
+		return c.errs[0]
+	}
+
+	total := c.dropped
+	for _, n := range c.counts {
+		total += n
+	}
+
+	b := &strings.Builder{}
+	_, _ = fmt.Fprintf(b, "%d errors:", total)
+	for i, e := range c.errs {
+		_, _ = fmt.Fprintf(b, "\n%d. %s", i+1, e)
+		if n := c.counts[i]; n > 1 {
+			_, _ = fmt.Fprintf(b, " (%d times)", n)
+		}
+	}
+
+	switch {
+	case c.dropped == 1:
+		b.WriteString("\nand 1 more error")
+	case c.dropped > 1:
+		_, _ = fmt.Fprintf(b, "\nand %d more errors", c.dropped)
+	}
+
+	return &collectedError{
+		errs: c.errs[:len(c.errs):len(c.errs)],
+		msg:  b.String(),
+	}
+}
+
+// collectedError is the error returned by [Collector.Err].
+type collectedError struct {
+	msg  string
+	errs []error
+}
+
+// type check
+var _ error = (*collectedError)(nil)
+
+// Error implements the error interface for *collectedError.
+func (err *collectedError) Error() (msg string) {
+	return err.msg
+}
+
+// type check
+var _ WrapperSlice = (*collectedError)(nil)
+
+// Unwrap implements the [WrapperSlice] interface for *collectedError.
+func (err *collectedError) Unwrap() (unwrapped []error) {
+	return err.errs
+}
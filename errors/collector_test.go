@@ -0,0 +1,38 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/AdguardTeam/golibs/errors"
+)
+
+func ExampleCollector() {
+	const (
+		errBadPort errors.Error = "bad port"
+		errNoHost  errors.Error = "no host"
+	)
+
+	c := errors.NewCollector(2)
+	fmt.Println(c.Err())
+
+	c.Add(fmt.Errorf("server 1: %w", errBadPort))
+	fmt.Println(c.Err())
+
+	c.Add(nil)
+	c.Add(fmt.Errorf("server 2: %w", errNoHost))
+	c.Add(fmt.Errorf("server 1: %w", errBadPort))
+	c.Add(fmt.Errorf("server 3: %w", errNoHost))
+
+	err := c.Err()
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, errNoHost), c.Len(), c.Dropped())
+
+	// Output:
+	// <nil>
+	// server 1: bad port
+	// 4 errors:
+	// 1. server 1: bad port (2 times)
+	// 2. server 2: no host
+	// and 1 more error
+	// true 2 1
+}
@@ -0,0 +1,157 @@
+package errors
+
+import "fmt"
+
+// Kind is the classification of an error, which helps callers decide how to
+// handle it, for example whether the operation should be retried.
+type Kind uint8
+
+// Error kinds.
+const (
+	// KindUnknown means that the kind of the error is not known.  It is the
+	// zero value.
+	KindUnknown Kind = iota
+
+	// KindTemporary means that the error is transient, for example a timeout,
+	// and that the operation can be retried as is.
+	KindTemporary
+
+	// KindNotFound means that the requested entity does not exist.
+	KindNotFound
+
+	// KindInvalidArgument means that the input is invalid and that retrying
+	// the operation with the same input will not help.
+	KindInvalidArgument
+
+	// KindUnavailable means that a required service or resource is currently
+	// unavailable, and that the operation can be retried later.
+	KindUnavailable
+)
+
+// type check
+var _ fmt.Stringer = KindUnknown
+
+// String implements the [fmt.Stringer] interface for Kind.
+func (k Kind) String() (s string) {
+	switch k {
+	case KindUnknown:
+		return "unknown"
+	case KindTemporary:
+		return "temporary"
+	case KindNotFound:
+		return "not_found"
+	case KindInvalidArgument:
+		return "invalid_argument"
+	case KindUnavailable:
+		return "unavailable"
+	default:
+		return fmt.Sprintf("!bad_error_kind_%d", k)
+	}
+}
+
+// IsRetryable returns true if the operations failed with errors of kind k can
+// be retried, that is, if k is [KindTemporary] or [KindUnavailable].
+func (k Kind) IsRetryable() (ok bool) {
+	return k == KindTemporary || k == KindUnavailable
+}
+
+// Kinder is the interface for errors that report their kind.  The method isn't
+// called Kind, because many error types already have fields with that name.
+type Kinder interface {
+	error
+
+	// ErrorKind returns the kind of the error.  If it returns [KindUnknown],
+	// the errors it wraps are inspected.
+	ErrorKind() (k Kind)
+}
+
+// timeouter is the interface for errors that report timeouts, such as
+// [net.Error] and [context.DeadlineExceeded].
+type timeouter interface {
+	Timeout() (ok bool)
+}
+
+// WithKind returns an error that wraps err and has the kind k, see [KindOf].
+// If err is nil, withKind is nil.
+func WithKind(err error, k Kind) (withKind error) {
+	if err == nil {
+		return nil
+	}
+
+	return &kindError{
+		err:  err,
+		kind: k,
+	}
+}
+
+// KindOf returns the kind of err.  It inspects the tree of err in the same
+// order as [Is] and returns the first known kind, so the kinds set by the
+// outer errors take precedence.  Errors implementing [Kinder] report their
+// kinds, and timeouts, such as [context.DeadlineExceeded], are considered to
+// be of [KindTemporary].  If no kind is known, k is [KindUnknown].
+func KindOf(err error) (k Kind) {
+	if err == nil {
+		return KindUnknown
+	}
+
+	k = ownKind(err)
+	if k != KindUnknown {
+		return k
+	}
+
+	switch err := err.(type) {
+	case WrapperSlice:
+		for _, e := range err.Unwrap() {
+			k = KindOf(e)
+			if k != KindUnknown {
+				return k
+			}
+		}
+	case Wrapper:
+		return KindOf(err.Unwrap())
+	}
+
+	return KindUnknown
+}
+
+// ownKind returns the kind of err without inspecting the errors it wraps.
+func ownKind(err error) (k Kind) {
+	switch err := err.(type) {
+	case Kinder:
+		return err.ErrorKind()
+	case timeouter:
+		if err.Timeout() {
+			return KindTemporary
+		}
+	}
+
+	return KindUnknown
+}
+
+// kindError is an error with a kind.
+type kindError struct {
+	err  error
+	kind Kind
+}
+
+// type check
+var _ Kinder = (*kindError)(nil)
+
+// Error implements the error interface for *kindError.  It returns the message
+// of the underlying error.
+func (err *kindError) Error() (msg string) {
+	return err.err.Error()
+}
+
+// ErrorKind implements the [Kinder] interface for *kindError.
+func (err *kindError) ErrorKind() (k Kind) {
+	return err.kind
+}
+
+// type check
+var _ Wrapper = (*kindError)(nil)
+
+// Unwrap implements the [Wrapper] interface for *kindError.
+func (err *kindError) Unwrap() (unwrapped error) {
+	return err.err
+}
@@ -0,0 +1,32 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AdguardTeam/golibs/errors"
+)
+
+func ExampleKindOf() {
+	const errNoUser errors.Error = "no user"
+
+	err := errors.Annotate(errors.WithKind(errNoUser, errors.KindNotFound), "getting user: %w")
+	fmt.Println(errors.KindOf(err), errors.KindOf(err).IsRetryable())
+
+	// Outer kinds take precedence.
+	err = errors.WithKind(err, errors.KindUnavailable)
+	fmt.Println(errors.KindOf(err), errors.KindOf(err).IsRetryable())
+
+	err = errors.Join(errNoUser, fmt.Errorf("waiting: %w", context.DeadlineExceeded))
+	fmt.Println(errors.KindOf(err), errors.KindOf(err).IsRetryable())
+
+	fmt.Println(errors.KindOf(errNoUser))
+	fmt.Println(errors.WithKind(nil, errors.KindNotFound))
+
+	// Output:
+	// not_found false
+	// unavailable true
+	// temporary true
+	// unknown
+	// <nil>
+}
@@ -0,0 +1,123 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// maxStackDepth is the maximum number of frames recorded by [WithStack].
+const maxStackDepth = 32
+
+// Frame is a single frame of a stack trace recorded by [WithStack].
+type Frame struct {
+	// Function is the fully qualified name of the function.
+	Function string
+
+	// File is the path to the source file.
+	File string
+
+	// Line is the line number within File.
+	Line int
+}
+
+// type check
+var _ fmt.Stringer = Frame{}
+
+// String implements the [fmt.Stringer] interface for Frame.
+func (f Frame) String() (s string) {
+	return fmt.Sprintf("%s %s:%d", f.Function, f.File, f.Line)
+}
+
+// StackTracer is the interface for errors with a stack trace, such as the ones
+// returned by [WithStack].  The method name is the one used by
+// github.com/pkg/errors, so that the stack trace is also understood by the
+// packages that support it, such as the Sentry SDK.
+type StackTracer interface {
+	error
+
+	// StackTrace returns the program counters of the stack trace, starting
+	// with the innermost frame.  pcs must not be modified.
+	StackTrace() (pcs []uintptr)
+}
+
+// WithStack returns an error that wraps err and contains the stack trace of
+// its caller.  If err is nil, withStack is nil.  If err already contains a stack
+// trace in its tree, err is returned as is, so that the stack trace is only
+// recorded once, at the point where the error has been created.
+//
+// The stack trace is preserved by [Annotate] and [Join] and can be retrieved
+// with [Stack].
+func WithStack(err error) (withStack error) {
+	if err == nil {
+		return nil
+	} else if _, ok := AsType[StackTracer](err); ok {
+		return err
+	}
+
+	pcs := make([]uintptr, maxStackDepth)
+
+	// Skip [runtime.Callers] and WithStack itself.
+	n := runtime.Callers(2, pcs)
+
+	return &stackError{
+		err: err,
+		pcs: pcs[:n:n],
+	}
+}
+
+// Stack returns the frames of the first stack trace found in the tree of err,
+// starting with the innermost frame.  It returns nil if there is none.
+func Stack(err error) (frames []Frame) {
+	st, ok := AsType[StackTracer](err)
+	if !ok {
+		return nil
+	}
+
+	pcs := st.StackTrace()
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	frames = make([]Frame, 0, len(pcs))
+	runtimeFrames := runtime.CallersFrames(pcs)
+	for {
+		f, more := runtimeFrames.Next()
+		frames = append(frames, Frame{
+			Function: f.Function,
+			File:     f.File,
+			Line:     f.Line,
+		})
+
+		if !more {
+			return frames
+		}
+	}
+}
+
+// stackError is an error with a stack trace.
+type stackError struct {
+	err error
+	pcs []uintptr
+}
+
+// type check
+var _ StackTracer = (*stackError)(nil)
+
+// Error implements the error interface for *stackError.  It returns the message
+// of the underlying error.
+func (err *stackError) Error() (msg string) {
+	return err.err.Error()
+}
+
+// StackTrace implements the [StackTracer] interface for *stackError.
+func (err *stackError) StackTrace() (pcs []uintptr) {
+	return err.pcs
+}
+
+// type check
+var _ Wrapper = (*stackError)(nil)
+
+// Unwrap implements the [Wrapper] interface for *stackError.
+func (err *stackError) Unwrap() (unwrapped error) {
+	return err.err
+}
@@ -0,0 +1,36 @@
+package errors_test
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/AdguardTeam/golibs/errors"
+)
+
+// newStackError is a helper for ExampleWithStack that creates an error with a
+// stack trace.
+func newStackError() (err error) {
+	return errors.WithStack(errors.Error("not found"))
+}
+
+func ExampleWithStack() {
+	err := errors.Annotate(newStackError(), "reading: %w")
+	err = errors.Join(errors.Error("first error"), err)
+
+	// The stack trace is recorded only once.
+	fmt.Println(errors.WithStack(err) == err)
+
+	frames := errors.Stack(err)
+	fmt.Println(frames[0].Function, filepath.Base(frames[0].File))
+	fmt.Println(frames[1].Function)
+
+	fmt.Println(errors.Stack(errors.Error("no stack")))
+	fmt.Println(errors.WithStack(nil))
+
+	// Output:
+	// true
+	// github.com/AdguardTeam/golibs/errors_test.newStackError stack_test.go
+	// github.com/AdguardTeam/golibs/errors_test.ExampleWithStack
+	// []
+	// <nil>
+}
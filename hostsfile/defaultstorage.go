@@ -15,30 +15,6 @@ import (
 	"github.com/AdguardTeam/golibs/logutil/slogutil"
 )
 
-// orderedSet is a helper type for storing values in original adding order and
-// dealing with duplicates.
-type orderedSet[K string | netip.Addr] struct {
-	set  *container.MapSet[K]
-	vals []K
-}
-
-// add adds val to os if it's not already there.
-//
-// TODO(f.setrakov): Get rid of this method and log duplicate records in
-// DefaultStorage.
-func (os *orderedSet[K]) add(key, val K) {
-	if !os.set.Has(key) {
-		os.set.Add(key)
-		os.vals = append(os.vals, val)
-	}
-}
-
-// Convenience aliases for [orderedSet].
-type (
-	namesSet = orderedSet[string]
-	addrsSet = orderedSet[netip.Addr]
-)
-
 // DefaultStorageConfig is configuration structure for *DefaultStorage.
 type DefaultStorageConfig struct {
 	// Logger is used for logging errors in the [DefaultStorage.HandleInvalid]
@@ -52,19 +28,32 @@ type DefaultStorageConfig struct {
 // DefaultStorage is a [Storage] that removes duplicates.  It also implements
 // the [HandleSet] interface and therefore can be used within [Parse].
 //
+// The lookups return the stored slices without copying them, so the callers
+// must not modify them.  Instead, [DefaultStorage.Delete] replaces the slices
+// it changes, so that the slices returned before stay valid.
+//
 // It must be initialized with [NewDefaultStorage].
 type DefaultStorage struct {
 	// logger is used for logging errors in the [DefaultStorage.HandleInvalid]
 	// function.
 	logger *slog.Logger
 
-	// names maps each address to its names in original case and in original
+	// pairs contains the pairs of addresses and lowercased names.  It is used
+	// to remove duplicates and to find the pairs to delete.
+	//
+	// NOTE:  names and addrs can't be replaced by pairs, since the names are
+	// compared case-insensitively, but ByAddr must return them in original
+	// case, and since [container.BiMultiMap] allocates a new slice on each
+	// lookup, while the lookups of s must not allocate.
+	pairs *container.BiMultiMap[netip.Addr, string]
+
+	// names maps each address to its names in original case, in original
 	// adding order without duplicates.
-	names map[netip.Addr]*namesSet
+	names map[netip.Addr][]string
 
-	// addrs maps each host to its addresses in original adding order without
-	// duplicates.
-	addrs map[string]*addrsSet
+	// addrs maps each lowercased name to its addresses, in original adding
+	// order without duplicates.
+	addrs map[string][]netip.Addr
 }
 
 // NewDefaultStorage parses data if hosts files format from readers and returns
@@ -76,8 +65,9 @@ func NewDefaultStorage(
 ) (s *DefaultStorage, err error) {
 	s = &DefaultStorage{
 		logger: cmp.Or(config.Logger, slog.Default()),
-		names:  map[netip.Addr]*namesSet{},
-		addrs:  map[string]*addrsSet{},
+		pairs:  container.NewBiMultiMap[netip.Addr, string](),
+		names:  map[netip.Addr][]string{},
+		addrs:  map[string][]netip.Addr{},
 	}
 
 	// TODO(e.burkov):  Consider joining errors.
@@ -96,29 +86,55 @@ var _ HandleSet = (*DefaultStorage)(nil)
 // Add implements the [Set] interface for *DefaultStorage.  It skips records
 // without hostnames, ignores duplicates and squashes the rest.
 func (s *DefaultStorage) Add(_ context.Context, rec *Record) {
-	names := s.names[rec.Addr]
-	if names == nil {
-		names = &namesSet{
-			set: container.NewMapSet[string](),
+	// TODO(f.setrakov): Log duplicate records.
+	for _, name := range rec.Names {
+		lowered := strings.ToLower(name)
+		if s.pairs.Add(rec.Addr, lowered) {
+			s.names[rec.Addr] = append(s.names[rec.Addr], name)
+			s.addrs[lowered] = append(s.addrs[lowered], rec.Addr)
 		}
-
-		s.names[rec.Addr] = names
 	}
+}
 
+// Delete deletes the hostnames of rec from the hostnames of rec.Addr.  The
+// hostnames are compared case-insensitively.  It returns the number of the
+// deleted hostnames.  The slices previously returned by s are not modified.
+func (s *DefaultStorage) Delete(_ context.Context, rec *Record) (n int) {
 	for _, name := range rec.Names {
 		lowered := strings.ToLower(name)
-		names.add(lowered, name)
-
-		addrs := s.addrs[lowered]
-		if addrs == nil {
-			addrs = &addrsSet{
-				vals: []netip.Addr{},
-				set:  container.NewMapSet[netip.Addr](),
-			}
-			s.addrs[lowered] = addrs
+		if !s.pairs.Delete(rec.Addr, lowered) {
+			continue
 		}
-		addrs.add(rec.Addr, rec.Addr)
+
+		n++
+
+		isLowered := func(host string) (ok bool) { return strings.ToLower(host) == lowered }
+		deleteFromSlices(s.names, rec.Addr, isLowered)
+
+		isAddr := func(a netip.Addr) (ok bool) { return a == rec.Addr }
+		deleteFromSlices(s.addrs, lowered, isAddr)
 	}
+
+	return n
+}
+
+// deleteFromSlices deletes the first element of m[k] for which del returns
+// true.  It doesn't modify the original slice, since it may have been returned
+// to the callers.  If m[k] becomes empty, k is deleted from m.
+func deleteFromSlices[K comparable, V any](m map[K][]V, k K, del func(v V) (ok bool)) {
+	vals := m[k]
+	i := slices.IndexFunc(vals, del)
+	if i < 0 {
+		return
+	}
+
+	if len(vals) == 1 {
+		delete(m, k)
+
+		return
+	}
+
+	m[k] = slices.Concat(vals[:i], vals[i+1:])
 }
 
 // HandleInvalid implements the [HandleSet] interface for *DefaultStorage.  It
@@ -146,22 +162,14 @@ var _ Storage = (*DefaultStorage)(nil)
 // each host for addr in original case, in original adding order without
 // duplicates.  It returns nil if h doesn't contain the addr.
 func (s *DefaultStorage) ByAddr(addr netip.Addr) (hosts []string) {
-	if hostsSet, ok := s.names[addr]; ok {
-		hosts = hostsSet.vals
-	}
-
-	return hosts
+	return s.names[addr]
 }
 
 // ByName implements the [Storage] interface for *DefaultStorage.  It returns
 // each address for host in original adding order without duplicates.  It
 // returns nil if h doesn't contain the host.
 func (s *DefaultStorage) ByName(host string) (addrs []netip.Addr) {
-	if addrsSet, ok := s.addrs[strings.ToLower(host)]; ok {
-		addrs = addrsSet.vals
-	}
-
-	return addrs
+	return s.addrs[strings.ToLower(host)]
 }
 
 // RangeNames ranges through all addresses in s and calls f with all the
@@ -169,7 +177,7 @@ func (s *DefaultStorage) ByName(host string) (addrs []netip.Addr) {
 // must not be modified.
 func (s *DefaultStorage) RangeNames(f func(addr netip.Addr, names []string) (cont bool)) {
 	for addr, names := range s.names {
-		if !f(addr, names.vals) {
+		if !f(addr, names) {
 			return
 		}
 	}
@@ -180,30 +188,26 @@ func (s *DefaultStorage) RangeNames(f func(addr netip.Addr, names []string) (con
 // addrs must not be modified.
 func (s *DefaultStorage) RangeAddrs(f func(host string, addrs []netip.Addr) (cont bool)) {
 	for host, addrs := range s.addrs {
-		if !f(host, addrs.vals) {
+		if !f(host, addrs) {
 			return
 		}
 	}
 }
 
-// Equal returns true if s and other contain the same addresses mapped to the
-// same hostnames in the same order.  Empty and nil storages are not equal.
+// Equal returns true if s and other contain the same pairs of addresses and
+// hostnames, with the hostnames of each address in the same case and in the
+// same order.  Empty and nil storages are not equal.
 func (s *DefaultStorage) Equal(other *DefaultStorage) (ok bool) {
 	if s == nil || other == nil {
 		return s == other
-	} else if len(s.names) != len(other.names) || len(s.addrs) != len(other.addrs) {
+	} else if s.pairs.Len() != other.pairs.Len() {
 		return false
 	}
 
-	// Don't use [maps.Equal] here, since we're only interested in comparing the
-	// fields of values, not the values itself.
+	// Since the numbers of pairs are equal, it's enough to make sure that all
+	// names of s are the same in other.
 	for addr, names := range s.names {
-		var otherNames *namesSet
-		switch otherNames, ok = other.names[addr]; {
-		case
-			!ok,
-			len(names.vals) != len(otherNames.vals),
-			!slices.Equal(names.vals, otherNames.vals):
+		if !slices.Equal(names, other.names[addr]) {
 			return false
 		}
 	}
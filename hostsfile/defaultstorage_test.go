@@ -300,6 +300,19 @@ func TestDefaultStorage_Equal(t *testing.T) {
 	)
 	require.NoError(t, err)
 
+	newStorage := func(hosts string) (s *hostsfile.DefaultStorage) {
+		s, err = hostsfile.NewDefaultStorage(
+			ctx,
+			&hostsfile.DefaultStorageConfig{
+				Logger:  testLogger,
+				Readers: []io.Reader{strings.NewReader(hosts)},
+			},
+		)
+		require.NoError(t, err)
+
+		return s
+	}
+
 	testCases := []struct {
 		a    *hostsfile.DefaultStorage
 		b    *hostsfile.DefaultStorage
@@ -340,6 +353,40 @@ func TestDefaultStorage_Equal(t *testing.T) {
 		a:    empty,
 		b:    hs1,
 		want: assert.False,
+	}, {
+		name: "same_different_lines",
+		a:    hs1,
+		b:    newStorage("4.3.2.1 yet.another.example\n" + hosts1),
+		want: assert.True,
+	}, {
+		name: "same_pairs_count",
+		a:    hs1,
+		b: newStorage("" +
+			"1.2.3.4 host.example\n" +
+			"4.3.2.1 another.example yet.another.example\n",
+		),
+		want: assert.False,
+	}, {
+		name: "more_pairs",
+		a:    hs1,
+		b:    newStorage(hosts1 + "5.6.7.8 host.example\n"),
+		want: assert.False,
+	}, {
+		name: "different_order",
+		a:    hs1,
+		b: newStorage("" +
+			"1.2.3.4 another.example host.example\n" +
+			"4.3.2.1 yet.another.example\n",
+		),
+		want: assert.False,
+	}, {
+		name: "different_case",
+		a:    hs1,
+		b: newStorage("" +
+			"1.2.3.4 Host.Example another.example\n" +
+			"4.3.2.1 yet.another.example\n",
+		),
+		want: assert.False,
 	}}
 
 	for _, tc := range testCases {
@@ -352,3 +399,57 @@ func TestDefaultStorage_Equal(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultStorage_Delete(t *testing.T) {
+	t.Parallel()
+
+	const hostsStr = `` +
+		"1.2.3.4 Host.Example another.example\n" +
+		"4.3.2.1 host.example\n"
+
+	var (
+		v4Addr1 = netip.MustParseAddr("1.2.3.4")
+		v4Addr2 = netip.MustParseAddr("4.3.2.1")
+	)
+
+	ctx := testutil.ContextWithTimeout(t, testTimeout)
+	ds, err := hostsfile.NewDefaultStorage(
+		ctx,
+		&hostsfile.DefaultStorageConfig{
+			Logger:  testLogger,
+			Readers: []io.Reader{strings.NewReader(hostsStr)},
+		},
+	)
+	require.NoError(t, err)
+
+	names := ds.ByAddr(v4Addr1)
+
+	n := ds.Delete(ctx, &hostsfile.Record{
+		Addr:  v4Addr1,
+		Names: []string{"HOST.example", "none.example"},
+	})
+	assert.Equal(t, 1, n)
+
+	// The previously returned slices must not be modified.
+	assert.Equal(t, []string{"Host.Example", "another.example"}, names)
+
+	assert.Equal(t, []string{"another.example"}, ds.ByAddr(v4Addr1))
+	assert.Equal(t, []netip.Addr{v4Addr2}, ds.ByName("host.example"))
+
+	n = ds.Delete(ctx, &hostsfile.Record{
+		Addr:  v4Addr2,
+		Names: []string{"host.example"},
+	})
+	assert.Equal(t, 1, n)
+
+	assert.Nil(t, ds.ByAddr(v4Addr2))
+	assert.Nil(t, ds.ByName("host.example"))
+
+	// Re-adding a name must use the new case.
+	ds.Add(ctx, &hostsfile.Record{
+		Addr:  v4Addr1,
+		Names: []string{"HOST.EXAMPLE"},
+	})
+
+	assert.Equal(t, []string{"another.example", "HOST.EXAMPLE"}, ds.ByAddr(v4Addr1))
+}
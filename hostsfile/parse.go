@@ -9,15 +9,20 @@ import (
 	"github.com/AdguardTeam/golibs/errors"
 )
 
+// maxParseErrors is the maximum number of unmarshaling errors returned by
+// [Parse].
+const maxParseErrors = 100
+
 // Parse reads src and parses it as a hosts file line by line using buf for
 // buffered scanning.  If src is a [NamedReader], the name of the data source
 // will be set to the Source field of each [Record].
 //
 // dst must not be nil, use [DiscardSet] if only the unmarshaling errors needed.
-// By default it returns all unmarshaling errors within err, but if dst is also
-// a [HandleSet], it will be used to handle invalid records and unmarshaling
-// errors wrapped with [LineError], see [Record.UnmarshalText] for returned
-// errors.
+// By default it returns the unmarshaling errors within err, see
+// [errors.Collector] for the format, but only the first 100 of them are kept.
+// If dst is also a [HandleSet], it will be used to handle invalid records and
+// unmarshaling errors wrapped with [LineError], see [Record.UnmarshalText] for
+// returned errors.
 func Parse(ctx context.Context, dst Set, src io.Reader, buf []byte) (err error) {
 	var srcName string
 	nr, ok := src.(NamedReader)
@@ -25,10 +30,10 @@ func Parse(ctx context.Context, dst Set, src io.Reader, buf []byte) (err error)
 		srcName = nr.Name()
 	}
 
-	var errs []error
-	// By default, collect all errors.
+	// By default, collect the errors.
+	errs := errors.NewCollector(maxParseErrors)
 	handleInvalid := func(_ context.Context, _ string, _ []byte, err error) {
-		errs = append(errs, err)
+		errs.Add(err)
 	}
 
 	if handleSet, isHandleSet := dst.(HandleSet); isHandleSet {
@@ -54,5 +59,5 @@ func Parse(ctx context.Context, dst Set, src io.Reader, buf []byte) (err error)
 		return fmt.Errorf("scanning: %w", err)
 	}
 
-	return errors.Annotate(errors.Join(errs...), "parsing: %w")
+	return errors.Annotate(errs.Err(), "parsing: %w")
 }
@@ -85,7 +85,7 @@ func TestParse(t *testing.T) {
 			Addr:  testIPv4,
 			Names: []string{"host1", "host2"},
 		}},
-		wantErrMsg: "parsing: line 1: line is empty\nline 2: line is empty",
+		wantErrMsg: "parsing: 2 errors:\n1. line 1: line is empty\n2. line 2: line is empty",
 	}, {
 		name: "two_records",
 		source: strings.NewReader(`
@@ -154,6 +154,24 @@ func TestParse_badReader(t *testing.T) {
 	require.ErrorIs(t, err, readErr)
 }
 
+func TestParse_manyErrors(t *testing.T) {
+	t.Parallel()
+
+	// linesNum is the number of invalid lines, which is greater than the
+	// number of errors kept by [hostsfile.Parse].
+	const linesNum = 150
+
+	src := strings.NewReader(strings.Repeat("# comment\n", linesNum))
+
+	ctx := testutil.ContextWithTimeout(t, testTimeout)
+	err := hostsfile.Parse(ctx, hostsfile.DiscardSet{}, src, nil)
+	require.ErrorIs(t, err, hostsfile.ErrEmptyLine)
+
+	msg := err.Error()
+	assert.True(t, strings.HasPrefix(msg, "parsing: 150 errors:\n1. line 1: line is empty\n"))
+	assert.True(t, strings.HasSuffix(msg, "\n100. line 100: line is empty\nand 50 more errors"))
+}
+
 func BenchmarkParse(b *testing.B) {
 	// linesNum defines the number of line in the file for benchmarking.
 	const linesNum = 1024
@@ -0,0 +1,135 @@
+package slogutil
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/AdguardTeam/golibs/errors"
+)
+
+// ErrorAttrsHandler is a [slog.Handler] that adds the attributes of the errors
+// under the [KeyError] key, see [errors.WithAttrs], right after those errors.
+// It also replaces such errors containing stack traces, see [errors.WithStack],
+// in the same way as [ReplaceErrorStack], so that the stack traces are logged
+// regardless of the wrapped handler.  All other work is delegated to the
+// wrapped handler.
+type ErrorAttrsHandler struct {
+	handler slog.Handler
+}
+
+// NewErrorAttrsHandler returns a new properly initialized *ErrorAttrsHandler
+// that wraps h.
+func NewErrorAttrsHandler(h slog.Handler) (eh *ErrorAttrsHandler) {
+	// As an optimization, avoid chains of ErrorAttrsHandlers.
+	eh, ok := h.(*ErrorAttrsHandler)
+	if ok {
+		h = eh.handler
+	}
+
+	return &ErrorAttrsHandler{
+		handler: h,
+	}
+}
+
+// type check
+var _ slog.Handler = (*ErrorAttrsHandler)(nil)
+
+// Enabled implements the [slog.Handler] interface for *ErrorAttrsHandler.
+func (h *ErrorAttrsHandler) Enabled(ctx context.Context, level slog.Level) (ok bool) {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle implements the [slog.Handler] interface for *ErrorAttrsHandler.
+func (h *ErrorAttrsHandler) Handle(ctx context.Context, r slog.Record) (err error) {
+	isExpandable := false
+	r.Attrs(func(a slog.Attr) (cont bool) {
+		isExpandable = isExpandableError(a)
+
+		return !isExpandable
+	})
+
+	if !isExpandable {
+		return h.handler.Handle(ctx, r)
+	}
+
+	expanded := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) (cont bool) {
+		expanded.AddAttrs(expandError(a)...)
+
+		return true
+	})
+
+	return h.handler.Handle(ctx, expanded)
+}
+
+// WithAttrs implements the [slog.Handler] interface for *ErrorAttrsHandler.
+func (h *ErrorAttrsHandler) WithAttrs(attrs []slog.Attr) (res slog.Handler) {
+	expanded := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		expanded = append(expanded, expandError(a)...)
+	}
+
+	return NewErrorAttrsHandler(h.handler.WithAttrs(expanded))
+}
+
+// WithGroup implements the [slog.Handler] interface for *ErrorAttrsHandler.
+func (h *ErrorAttrsHandler) WithGroup(name string) (res slog.Handler) {
+	return NewErrorAttrsHandler(h.handler.WithGroup(name))
+}
+
+// Handler returns the slog.Handler wrapped by h.
+func (h *ErrorAttrsHandler) Handler() (unwrapped slog.Handler) {
+	return h.handler
+}
+
+// errorValue returns the error in a if a is a [KeyError] attribute.
+func errorValue(a slog.Attr) (err error, ok bool) {
+	if a.Key != KeyError || a.Value.Kind() != slog.KindAny {
+		return nil, false
+	}
+
+	err, ok = a.Value.Any().(error)
+
+	return err, ok
+}
+
+// isExpandableError returns true if a is a [KeyError] attribute with an error
+// containing a stack trace or attributes.
+func isExpandableError(a slog.Attr) (ok bool) {
+	err, ok := errorValue(a)
+	if !ok {
+		return false
+	}
+
+	_, ok = errors.AsType[errors.StackTracer](err)
+
+	return ok || len(errors.Attrs(err)) > 0
+}
+
+// expandError returns a, with the stack trace expanded, followed by the
+// attributes of the error in a, if a is a [KeyError] attribute.  Otherwise, it
+// returns a as is.
+func expandError(a slog.Attr) (attrs []slog.Attr) {
+	err, ok := errorValue(a)
+	if !ok {
+		return []slog.Attr{a}
+	}
+
+	frames := errors.Stack(err)
+	if frames != nil {
+		a = newErrorStackAttr(err, frames)
+	}
+
+	return append([]slog.Attr{a}, errors.Attrs(err)...)
+}
+
+// newErrorStackAttr returns a group attribute under the [KeyError] key with the
+// message of err under the "msg" key and frames under the "stack" key.
+func newErrorStackAttr(err error, frames []errors.Frame) (a slog.Attr) {
+	stack := make([]string, 0, len(frames))
+	for _, f := range frames {
+		stack = append(stack, f.String())
+	}
+
+	return slog.Group(KeyError, "msg", err.Error(), "stack", stack)
+}
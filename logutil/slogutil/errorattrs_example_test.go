@@ -0,0 +1,28 @@
+package slogutil_test
+
+import (
+	"log/slog"
+
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/AdguardTeam/golibs/logutil/slogutil"
+)
+
+func ExampleErrorAttrsHandler() {
+	l := slogutil.New(&slogutil.Config{
+		Format: slogutil.FormatText,
+	})
+
+	const errNotFound errors.Error = "not found"
+
+	err := errors.WithAttrs(errNotFound, slog.String("host", "example.com"))
+	err = errors.Annotate(err, "resolving: %w")
+
+	l.Info("lookup failed", slogutil.KeyError, err, "attempt", 1)
+	l.With(slogutil.KeyError, err).Info("lookup failed again")
+	l.Info("no attrs", slogutil.KeyError, errNotFound)
+
+	// Output:
+	// level=INFO msg="lookup failed" err="resolving: not found" host=example.com attempt=1
+	// level=INFO msg="lookup failed again" err="resolving: not found" host=example.com
+	// level=INFO msg="no attrs" err="not found"
+}
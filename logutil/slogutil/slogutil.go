@@ -12,6 +12,8 @@ import (
 	"os"
 	"runtime/debug"
 	"strings"
+
+	"github.com/AdguardTeam/golibs/errors"
 )
 
 // Additional or renamed key constants.
@@ -43,7 +45,8 @@ type Config struct {
 }
 
 // New creates a slog logger with the given parameters.  If c is nil, the
-// defaults are used.
+// defaults are used.  The attributes and the stack traces of logged errors are
+// added to the records, see [ErrorAttrsHandler].
 //
 // NOTE: If c.Format is [FormatAdGuardLegacy], the legacy logger parameters,
 // such as output, should be set separately.
@@ -91,14 +94,14 @@ func New(c *Config) (l *slog.Logger) {
 		})
 	}
 
-	return slog.New(h)
+	return slog.New(NewErrorAttrsHandler(h))
 }
 
 // newDefault returns a new default slog logger set up with the given options.
 //
 // TODO(d.kolyshev): Replace log level name for [LevelTrace].
 func newDefault(output io.Writer, lvl slog.Leveler, addTimestamp bool) (l *slog.Logger) {
-	h := NewLevelHandler(lvl, slog.Default().Handler())
+	h := NewErrorAttrsHandler(NewLevelHandler(lvl, slog.Default().Handler()))
 	log.SetOutput(output)
 	if addTimestamp {
 		log.SetFlags(log.LstdFlags | log.Lmicroseconds)
@@ -155,6 +158,26 @@ func RemoveTime(groups []string, a slog.Attr) (res slog.Attr) {
 	return a
 }
 
+// ReplaceErrorStack is a function for [slog.HandlerOptions.ReplaceAttr] that
+// replaces [KeyError] attributes containing errors with stack traces, see
+// [errors.WithStack], with groups containing the error message under the "msg"
+// key and the frames under the "stack" key.
+//
+// Loggers created with [New] already do that, see [ErrorAttrsHandler].
+func ReplaceErrorStack(_ []string, a slog.Attr) (res slog.Attr) {
+	err, ok := errorValue(a)
+	if !ok {
+		return a
+	}
+
+	frames := errors.Stack(err)
+	if frames == nil {
+		return a
+	}
+
+	return newErrorStackAttr(err, frames)
+}
+
 // PrintStack logs the stacktrace into l on the given level.
 func PrintStack(ctx context.Context, l *slog.Logger, lvl slog.Level) {
 	stack := bytes.Split(debug.Stack(), []byte{'\n'})
@@ -1 +1,81 @@
 package slogutil_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/AdguardTeam/golibs/logutil/slogutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceErrorStack(t *testing.T) {
+	t.Parallel()
+
+	const testError errors.Error = "test error"
+
+	buf := &bytes.Buffer{}
+	l := slogutil.New(&slogutil.Config{
+		Output: buf,
+		Format: slogutil.FormatJSON,
+	})
+
+	l.Info("no stack", slogutil.KeyError, testError)
+	l.Info("stack", slogutil.KeyError, errors.Annotate(errors.WithStack(testError), "wrapped: %w"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	noStack := map[string]any{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &noStack))
+
+	assert.Equal(t, testError.Error(), noStack[slogutil.KeyError])
+
+	withStack := struct {
+		Err struct {
+			Msg   string   `json:"msg"`
+			Stack []string `json:"stack"`
+		} `json:"err"`
+	}{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &withStack))
+
+	assert.Equal(t, "wrapped: test error", withStack.Err.Msg)
+	require.NotEmpty(t, withStack.Err.Stack)
+
+	assert.Contains(t, withStack.Err.Stack[0], "slogutil_test.TestReplaceErrorStack")
+
+	a := slog.Any(slogutil.KeyPrefix, errors.WithStack(testError))
+	assert.Equal(t, a, slogutil.ReplaceErrorStack(nil, a))
+}
+
+// NOTE:  Don't use t.Parallel, because the default format uses the global
+// logger of package log.
+func TestNew_defaultErrorStack(t *testing.T) {
+	const testError errors.Error = "test error"
+
+	// Restore the default output of the global logger of package log.
+	t.Cleanup(func() {
+		_ = slogutil.New(&slogutil.Config{
+			Output: os.Stderr,
+			Format: slogutil.FormatDefault,
+		})
+	})
+
+	buf := &bytes.Buffer{}
+	l := slogutil.New(&slogutil.Config{
+		Output: buf,
+		Format: slogutil.FormatDefault,
+	})
+
+	l.Info("stack", slogutil.KeyError, errors.WithStack(testError))
+
+	line := buf.String()
+	assert.Contains(t, line, "err.msg=\"test error\"")
+	assert.Contains(t, line, "err.stack=")
+	assert.Contains(t, line, "slogutil_test.TestNew_defaultErrorStack")
+}
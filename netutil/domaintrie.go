@@ -0,0 +1,222 @@
+package netutil
+
+import (
+	"strings"
+)
+
+// DomainTrie is a set of domain names with attached values.  The names are
+// stored as a trie of their labels in the reverse order, so finding out if a
+// domain name or any of its parent domains is in the set only takes time
+// proportional to the number of its labels.  It must be initialized with
+// [NewDomainTrie].  It is not safe for concurrent use.
+//
+// The names are not normalized, so they should be non-fully-qualified.  The
+// lookups are case-sensitive, just like [IsSubdomain], so the names should have
+// the same letter case as the names used for lookups.  Names with empty
+// labels, including fully-qualified ones, are never found.
+type DomainTrie[V any] struct {
+	root *domainTrieNode[V]
+	len  int
+}
+
+// domainTrieNode is a node of a [DomainTrie].
+type domainTrieNode[V any] struct {
+	// children are the nodes of the subdomains by their labels.
+	children map[string]*domainTrieNode[V]
+
+	value V
+
+	// isSet is true if the node corresponds to a name in the set.
+	isSet bool
+}
+
+// NewDomainTrie returns a new empty *DomainTrie.
+func NewDomainTrie[V any]() (t *DomainTrie[V]) {
+	return &DomainTrie[V]{
+		root: &domainTrieNode[V]{},
+	}
+}
+
+// cutLastLabel returns the last label of name and the rest of name without the
+// separating dot.
+func cutLastLabel(name string) (rest, label string) {
+	i := strings.LastIndexByte(name, '.')
+	if i < 0 {
+		return "", name
+	}
+
+	return name[:i], name[i+1:]
+}
+
+// hasEmptyLabels returns true if name is empty or has empty labels, that is,
+// starts or ends with a dot or contains two dots in a row.  Such names can't be
+// in a [DomainTrie].
+func hasEmptyLabels(name string) (ok bool) {
+	return name == "" ||
+		name[0] == '.' ||
+		name[len(name)-1] == '.' ||
+		strings.Contains(name, "..")
+}
+
+// Set sets the value for name, which must be a valid domain name.  Any error
+// returned will have the underlying type of [*AddrError].
+func (t *DomainTrie[V]) Set(name string, v V) (err error) {
+	err = ValidateDomainName(name)
+	if err != nil {
+		// Don't wrap the error, because it's informative enough as is.
+		return err
+	}
+
+	n := t.root
+	for rest, label := cutLastLabel(name); label != ""; rest, label = cutLastLabel(rest) {
+		child, ok := n.children[label]
+		if !ok {
+			if n.children == nil {
+				n.children = map[string]*domainTrieNode[V]{}
+			}
+
+			child = &domainTrieNode[V]{}
+			n.children[label] = child
+		}
+
+		n = child
+	}
+
+	if !n.isSet {
+		t.len++
+	}
+
+	n.value, n.isSet = v, true
+
+	return nil
+}
+
+// Get returns the value for name.  ok is false if name itself is not in t.
+func (t *DomainTrie[V]) Get(name string) (v V, ok bool) {
+	if hasEmptyLabels(name) {
+		return v, false
+	}
+
+	n := t.root
+	for rest, label := cutLastLabel(name); label != ""; rest, label = cutLastLabel(rest) {
+		n, ok = n.children[label]
+		if !ok {
+			return v, false
+		}
+	}
+
+	return n.value, n.isSet
+}
+
+// Contains returns true if name or any of its parent domains is in t.
+func (t *DomainTrie[V]) Contains(name string) (ok bool) {
+	_, _, ok = t.LongestMatch(name)
+
+	return ok
+}
+
+// LongestMatch returns the longest suffix of name that is in t, which is
+// either name itself or the closest of its parent domains, and its value.  ok
+// is false if there is no such suffix.
+func (t *DomainTrie[V]) LongestMatch(name string) (match string, v V, ok bool) {
+	if hasEmptyLabels(name) {
+		return "", v, false
+	}
+
+	n := t.root
+	for rest, label := cutLastLabel(name); label != ""; rest, label = cutLastLabel(rest) {
+		var hasChild bool
+		n, hasChild = n.children[label]
+		if !hasChild {
+			break
+		}
+
+		if n.isSet {
+			// The match is the part of name after the remaining labels and
+			// the dot.
+			match, v, ok = name, n.value, true
+			if rest != "" {
+				match = name[len(rest)+1:]
+			}
+		}
+	}
+
+	return match, v, ok
+}
+
+// Delete deletes name from t and returns true if it was there.  The
+// subdomains of name are not deleted.
+func (t *DomainTrie[V]) Delete(name string) (ok bool) {
+	if hasEmptyLabels(name) {
+		return false
+	}
+
+	ok = t.root.delete(name)
+	if ok {
+		t.len--
+	}
+
+	return ok
+}
+
+// delete deletes name relative to n and returns true if it was there.  The
+// nodes left without names are removed.
+func (n *domainTrieNode[V]) delete(name string) (ok bool) {
+	if name == "" {
+		if !n.isSet {
+			return false
+		}
+
+		var zero V
+		n.value, n.isSet = zero, false
+
+		return true
+	}
+
+	rest, label := cutLastLabel(name)
+	child, hasChild := n.children[label]
+	if !hasChild || !child.delete(rest) {
+		return false
+	}
+
+	if !child.isSet && len(child.children) == 0 {
+		delete(n.children, label)
+	}
+
+	return true
+}
+
+// Len returns the number of names in t.
+func (t *DomainTrie[V]) Len() (n int) {
+	return t.len
+}
+
+// Range calls f with each name in t and its value until f returns false.  The
+// order of the names is undefined.  f must not modify t.
+func (t *DomainTrie[V]) Range(f func(name string, v V) (cont bool)) {
+	_ = t.root.rangeNames("", f)
+}
+
+// rangeNames calls f with each name of n and its subdomains, where name is the
+// name of n, until f returns false.  cont is false if f returned false.
+func (n *domainTrieNode[V]) rangeNames(
+	name string,
+	f func(name string, v V) (cont bool),
+) (cont bool) {
+	if n.isSet && !f(name, n.value) {
+		return false
+	}
+
+	for label, child := range n.children {
+		childName := label
+		if name != "" {
+			childName = label + "." + name
+		}
+
+		if !child.rangeNames(childName, f) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,43 @@
+package netutil_test
+
+import (
+	"fmt"
+
+	"github.com/AdguardTeam/golibs/netutil"
+)
+
+func ExampleDomainTrie() {
+	blocked := netutil.NewDomainTrie[string]()
+
+	for name, reason := range map[string]string{
+		"ads.example":       "ads",
+		"tracker.example":   "tracking",
+		"cdn.ads.example":   "allowed cdn",
+		"malware.test":      "malware",
+		"www.malware.test":  "malware mirror",
+		"other.malware.org": "malware",
+	} {
+		err := blocked.Set(name, reason)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	for _, name := range []string{
+		"ads.example",
+		"banner.ads.example",
+		"img.cdn.ads.example",
+		"example",
+		"malware.org",
+	} {
+		match, reason, ok := blocked.LongestMatch(name)
+		fmt.Printf("%s: %t %q %q\n", name, ok, match, reason)
+	}
+
+	// Output:
+	// ads.example: true "ads.example" "ads"
+	// banner.ads.example: true "ads.example" "ads"
+	// img.cdn.ads.example: true "cdn.ads.example" "allowed cdn"
+	// example: false "" ""
+	// malware.org: false "" ""
+}
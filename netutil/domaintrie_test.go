@@ -0,0 +1,237 @@
+package netutil_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/netutil"
+	"github.com/AdguardTeam/golibs/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestDomainTrie returns a new domain trie with the given names, each with
+// its index as the value.
+func newTestDomainTrie(tb testing.TB, names ...string) (t *netutil.DomainTrie[int]) {
+	tb.Helper()
+
+	t = netutil.NewDomainTrie[int]()
+	for i, name := range names {
+		require.NoError(tb, t.Set(name, i))
+	}
+
+	return t
+}
+
+func TestDomainTrie_LongestMatch(t *testing.T) {
+	t.Parallel()
+
+	trie := newTestDomainTrie(t, "com", exampleDomain, "a.b.example.com", "example.org")
+
+	testCases := []struct {
+		name      string
+		in        string
+		wantMatch string
+		wantVal   int
+		wantOK    bool
+	}{{
+		name:      "exact",
+		in:        exampleDomain,
+		wantMatch: exampleDomain,
+		wantVal:   1,
+		wantOK:    true,
+	}, {
+		name:      "parent",
+		in:        "www.example.com",
+		wantMatch: exampleDomain,
+		wantVal:   1,
+		wantOK:    true,
+	}, {
+		name:      "intermediate_not_set",
+		in:        "b.example.com",
+		wantMatch: exampleDomain,
+		wantVal:   1,
+		wantOK:    true,
+	}, {
+		name:      "deep",
+		in:        "c.a.b.example.com",
+		wantMatch: "a.b.example.com",
+		wantVal:   2,
+		wantOK:    true,
+	}, {
+		name:      "tld",
+		in:        "other.com",
+		wantMatch: "com",
+		wantVal:   0,
+		wantOK:    true,
+	}, {
+		name:      "parent_not_set",
+		in:        "org",
+		wantMatch: "",
+		wantVal:   0,
+		wantOK:    false,
+	}, {
+		name:      "label_suffix",
+		in:        "badexample.org",
+		wantMatch: "",
+		wantVal:   0,
+		wantOK:    false,
+	}, {
+		name:      "empty",
+		in:        "",
+		wantMatch: "",
+		wantVal:   0,
+		wantOK:    false,
+	}, {
+		name:      "empty_label",
+		in:        "x..example.com",
+		wantMatch: "",
+		wantVal:   0,
+		wantOK:    false,
+	}, {
+		name:      "leading_dot",
+		in:        ".example.com",
+		wantMatch: "",
+		wantVal:   0,
+		wantOK:    false,
+	}, {
+		name:      "fqdn",
+		in:        "example.com.",
+		wantMatch: "",
+		wantVal:   0,
+		wantOK:    false,
+	}, {
+		name:      "case",
+		in:        "EXAMPLE.COM",
+		wantMatch: "",
+		wantVal:   0,
+		wantOK:    false,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			match, v, ok := trie.LongestMatch(tc.in)
+			assert.Equal(t, tc.wantMatch, match)
+			assert.Equal(t, tc.wantVal, v)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantOK, trie.Contains(tc.in))
+		})
+	}
+}
+
+func TestDomainTrie_Get(t *testing.T) {
+	t.Parallel()
+
+	trie := newTestDomainTrie(t, exampleDomain)
+
+	testCases := []struct {
+		name   string
+		in     string
+		wantOK bool
+	}{{
+		name:   "exact",
+		in:     exampleDomain,
+		wantOK: true,
+	}, {
+		name:   "empty_label",
+		in:     "x..example.com",
+		wantOK: false,
+	}, {
+		name:   "leading_dot",
+		in:     ".example.com",
+		wantOK: false,
+	}, {
+		name:   "fqdn",
+		in:     "example.com.",
+		wantOK: false,
+	}, {
+		name:   "case",
+		in:     "Example.com",
+		wantOK: false,
+	}, {
+		name:   "empty",
+		in:     "",
+		wantOK: false,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, ok := trie.Get(tc.in)
+			assert.Equal(t, tc.wantOK, ok)
+		})
+	}
+}
+
+func TestDomainTrie(t *testing.T) {
+	t.Parallel()
+
+	trie := newTestDomainTrie(t, exampleDomain, "www.example.com")
+	assert.Equal(t, 2, trie.Len())
+
+	_, ok := trie.Get("com")
+	assert.False(t, ok)
+
+	v, ok := trie.Get("www.example.com")
+	require.True(t, ok)
+
+	assert.Equal(t, 1, v)
+
+	require.NoError(t, trie.Set("www.example.com", 42))
+	assert.Equal(t, 2, trie.Len())
+
+	got := map[string]int{}
+	trie.Range(func(name string, v int) (cont bool) {
+		got[name] = v
+
+		return true
+	})
+	assert.Equal(t, map[string]int{exampleDomain: 0, "www.example.com": 42}, got)
+
+	assert.False(t, trie.Delete("com"))
+	assert.False(t, trie.Delete(".example.com"))
+	assert.True(t, trie.Delete(exampleDomain))
+	assert.False(t, trie.Delete(exampleDomain))
+	assert.Equal(t, 1, trie.Len())
+
+	// The subdomains are kept.
+	assert.False(t, trie.Contains("example.com"))
+	assert.True(t, trie.Contains("a.www.example.com"))
+
+	assert.True(t, trie.Delete("www.example.com"))
+	assert.Zero(t, trie.Len())
+	assert.False(t, trie.Contains("a.www.example.com"))
+
+	err := trie.Set("bad..name", 1)
+	testutil.AssertErrorMsg(
+		t,
+		`bad domain name "bad..name": bad domain name label "": domain name label is empty`,
+		err,
+	)
+}
+
+func BenchmarkDomainTrie_Contains(b *testing.B) {
+	trie := netutil.NewDomainTrie[struct{}]()
+	for i := range 100_000 {
+		require.NoError(b, trie.Set("domain-"+strconv.Itoa(i)+".example", struct{}{}))
+	}
+
+	var ok bool
+
+	b.ReportAllocs()
+	for b.Loop() {
+		ok = trie.Contains("www.sub.domain-12345.example")
+	}
+
+	assert.True(b, ok)
+
+	// Most recent results:
+	//	goos: linux
+	//	goarch: amd64
+	//	pkg: github.com/AdguardTeam/golibs/netutil
+	//	cpu: Intel(R) Xeon(R) Processor
+	//	BenchmarkDomainTrie_Contains 	12758562	       104.6 ns/op	       0 B/op	       0 allocs/op
+}
@@ -62,6 +62,15 @@ func (err *AddrError) Error() (msg string) {
 	return fmt.Sprintf("bad %s %q", err.Kind, err.Addr)
 }
 
+// type check
+var _ errors.Kinder = (*AddrError)(nil)
+
+// ErrorKind implements the [errors.Kinder] interface for *AddrError.  It
+// always returns [errors.KindInvalidArgument].
+func (err *AddrError) ErrorKind() (k errors.Kind) {
+	return errors.KindInvalidArgument
+}
+
 // type check
 var _ errors.Wrapper = (*AddrError)(nil)
 
@@ -125,6 +134,15 @@ func (err *LabelError) Error() (msg string) {
 	return fmt.Sprintf("bad %s %q", err.Kind, err.Label)
 }
 
+// type check
+var _ errors.Kinder = (*LabelError)(nil)
+
+// ErrorKind implements the [errors.Kinder] interface for *LabelError.  It
+// always returns [errors.KindInvalidArgument].
+func (err *LabelError) ErrorKind() (k errors.Kind) {
+	return errors.KindInvalidArgument
+}
+
 // type check
 var _ errors.Wrapper = (*AddrError)(nil)
 
@@ -191,6 +209,15 @@ func (err *LengthError) Error() (msg string) {
 	return fmt.Sprintf(format, err.Kind, err.Length, err.Allowed)
 }
 
+// type check
+var _ errors.Kinder = (*LengthError)(nil)
+
+// ErrorKind implements the [errors.Kinder] interface for *LengthError.  It
+// always returns [errors.KindInvalidArgument].
+func (err *LengthError) ErrorKind() (k errors.Kind) {
+	return errors.KindInvalidArgument
+}
+
 // RuneError is the underlying type of errors returned from validation functions
 // when a rune in the address is invalid.  Kind is either [AddrKind] or
 // [LabelKind].
@@ -210,6 +237,15 @@ func (err *RuneError) Error() (msg string) {
 	return fmt.Sprintf("bad %s rune %q", err.Kind, err.Rune)
 }
 
+// type check
+var _ errors.Kinder = (*RuneError)(nil)
+
+// ErrorKind implements the [errors.Kinder] interface for *RuneError.  It
+// always returns [errors.KindInvalidArgument].
+func (err *RuneError) ErrorKind() (k errors.Kind) {
+	return errors.KindInvalidArgument
+}
+
 // replaceKind replaces the Kind field of err with newKind.  err must be of type
 // [*LabelError], [*AddrError], [*LengthError], or [*RuneError].
 func replaceKind(err error, newKind string) {
@@ -0,0 +1,38 @@
+package netutil_test
+
+import (
+	"testing"
+
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/AdguardTeam/golibs/netutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorKind(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		err  error
+		name string
+	}{{
+		err:  netutil.ValidateDomainName(""),
+		name: "empty",
+	}, {
+		err:  netutil.ValidateHostname("bad!.example"),
+		name: "bad_rune",
+	}, {
+		err:  netutil.ValidateDomainName("a..example"),
+		name: "empty_label",
+	}, {
+		err:  netutil.ValidateMAC([]byte{1, 2, 3}),
+		name: "bad_mac",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, errors.KindInvalidArgument, errors.KindOf(tc.err))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package netutil
+
+import (
+	"net/netip"
+
+	"github.com/AdguardTeam/golibs/container"
+)
+
+// RangeSubnetSet is the [SubnetSet] that checks the address through a range
+// set of addresses.  Unlike [SliceSubnetSet], it takes O(log n) time to check
+// an address, where n is the number of networks, so it should be used for
+// large sets of networks.  It must be initialized with [NewRangeSubnetSet].
+type RangeSubnetSet struct {
+	addrs *container.ClosedRangeSet[netip.Addr]
+}
+
+// NewRangeSubnetSet returns a new *RangeSubnetSet containing the addresses from
+// nets.  Invalid prefixes are ignored.
+func NewRangeSubnetSet(nets ...netip.Prefix) (s *RangeSubnetSet) {
+	s = &RangeSubnetSet{
+		addrs: container.NewClosedRangeSet[netip.Addr](),
+	}
+
+	for _, n := range nets {
+		if n.IsValid() {
+			n = n.Masked()
+			s.addrs.Add(container.ClosedInterval[netip.Addr]{
+				First: n.Addr(),
+				Last:  lastAddr(n),
+			})
+		}
+	}
+
+	return s
+}
+
+// lastAddr returns the last address of p.  p must be valid and masked.
+func lastAddr(p netip.Prefix) (last netip.Addr) {
+	ip := p.Addr().As16()
+	bits := p.Bits()
+	if p.Addr().Is4() {
+		// Account for the IPv4-mapped prefix of the 16-byte form.
+		bits += 96
+	}
+
+	for i := bits; i < len(ip)*8; i++ {
+		ip[i/8] |= 1 << (7 - i%8)
+	}
+
+	last = netip.AddrFrom16(ip)
+	if p.Addr().Is4() {
+		return last.Unmap()
+	}
+
+	return last
+}
+
+// type check
+var _ SubnetSet = (*RangeSubnetSet)(nil)
+
+// Contains implements the [SubnetSet] interface for *RangeSubnetSet.
+func (s *RangeSubnetSet) Contains(ip netip.Addr) (ok bool) {
+	return ip.IsValid() && ip.Zone() == "" && s.addrs.Has(ip)
+}
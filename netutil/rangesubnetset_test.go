@@ -0,0 +1,76 @@
+package netutil_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/netutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeSubnetSet_Contains(t *testing.T) {
+	t.Parallel()
+
+	s := netutil.NewRangeSubnetSet(
+		netip.MustParsePrefix("192.0.2.1/24"),
+		netip.MustParsePrefix("192.0.3.0/24"),
+		netip.MustParsePrefix("255.255.255.254/31"),
+		netip.MustParsePrefix("2001:db8::/32"),
+		netip.MustParsePrefix("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128"),
+		netip.Prefix{},
+	)
+
+	testCases := []struct {
+		ip   netip.Addr
+		name string
+		want bool
+	}{{
+		ip:   netip.MustParseAddr("192.0.2.0"),
+		name: "first",
+		want: true,
+	}, {
+		ip:   netip.MustParseAddr("192.0.3.255"),
+		name: "last_merged",
+		want: true,
+	}, {
+		ip:   netip.MustParseAddr("192.0.4.0"),
+		name: "after_last",
+		want: false,
+	}, {
+		ip:   netip.MustParseAddr("255.255.255.255"),
+		name: "max_v4",
+		want: true,
+	}, {
+		ip:   netip.MustParseAddr("2001:db8:ffff:ffff:ffff:ffff:ffff:ffff"),
+		name: "last_v6",
+		want: true,
+	}, {
+		ip:   netip.MustParseAddr("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"),
+		name: "max_v6",
+		want: true,
+	}, {
+		ip:   netip.MustParseAddr("::"),
+		name: "after_max_v4",
+		want: false,
+	}, {
+		ip:   netip.MustParseAddr("::ffff:192.0.2.1"),
+		name: "mapped",
+		want: false,
+	}, {
+		ip:   netip.MustParseAddr("2001:db8::1%eth0"),
+		name: "zone",
+		want: false,
+	}, {
+		ip:   netip.Addr{},
+		name: "zero",
+		want: false,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, s.Contains(tc.ip))
+		})
+	}
+}
@@ -134,3 +134,24 @@ func ExampleSubnetSetFunc_Contains() {
 	// contains ff00:::1:  true
 	// contains ff:::1:    false
 }
+
+func ExampleRangeSubnetSet_Contains() {
+	s := netutil.NewRangeSubnetSet(
+		netip.MustParsePrefix("1.2.3.0/24"),
+		netip.MustParsePrefix("255.255.255.255/32"),
+		netip.MustParsePrefix("ffff:12ab::/16"),
+	)
+
+	fmt.Println("contains 1.2.3.4:        ", s.Contains(netip.MustParseAddr("1.2.3.4")))
+	fmt.Println("contains 4.3.2.1:        ", s.Contains(netip.MustParseAddr("4.3.2.1")))
+	fmt.Println("contains 255.255.255.255:", s.Contains(netip.MustParseAddr("255.255.255.255")))
+	fmt.Println("contains ffff:12ab::10:  ", s.Contains(netip.MustParseAddr("ffff:12ab::10")))
+	fmt.Println("contains 12ab:ffff::10:  ", s.Contains(netip.MustParseAddr("12ab:ffff::10")))
+
+	// Output:
+	// contains 1.2.3.4:         true
+	// contains 4.3.2.1:         false
+	// contains 255.255.255.255: true
+	// contains ffff:12ab::10:   true
+	// contains 12ab:ffff::10:   false
+}
@@ -129,6 +129,10 @@ var (
 		reference: specialPurposePrefixes,
 		set:       netutil.SubnetSetFunc(netutil.IsSpecialPurpose),
 		name:      "is_special_purpose",
+	}, {
+		reference: specialPurposePrefixes,
+		set:       netutil.NewRangeSubnetSet(specialPurposePrefixes...),
+		name:      "range_set",
 	}}
 )
 
@@ -291,6 +295,9 @@ func BenchmarkSliceSubnetSet_comparison(b *testing.B) {
 	}, {
 		set:  optimized,
 		name: "func_set",
+	}, {
+		set:  netutil.NewRangeSubnetSet(specialPurposePrefixes...),
+		name: "range_set",
 	}}
 
 	versionCases := []struct {
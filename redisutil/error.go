@@ -0,0 +1,67 @@
+package redisutil
+
+import (
+	"strings"
+
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/gomodule/redigo/redis"
+)
+
+// Prefixes of Redis error replies used for classification.
+const (
+	errPrefixBUSY       = "BUSY "
+	errPrefixLOADING    = "LOADING "
+	errPrefixMASTERDOWN = "MASTERDOWN "
+	errPrefixREADONLY   = "READONLY "
+	errPrefixTRYAGAIN   = "TRYAGAIN "
+	errPrefixWRONGTYPE  = "WRONGTYPE "
+)
+
+// KindOf returns the kind of err, see [errors.KindOf].  If the kind is not
+// known, it also classifies the errors of the Redis client:
+//   - [redis.ErrNil] and [ErrStrFunctionNotFound] replies have the kind
+//     [errors.KindNotFound];
+//   - [redis.ErrPoolExhausted] as well as the BUSY, LOADING, MASTERDOWN, and
+//     READONLY replies have the kind [errors.KindUnavailable];
+//   - TRYAGAIN replies have the kind [errors.KindTemporary];
+//   - WRONGTYPE replies have the kind [errors.KindInvalidArgument].
+func KindOf(err error) (k errors.Kind) {
+	k = errors.KindOf(err)
+	if k != errors.KindUnknown {
+		return k
+	}
+
+	switch {
+	case errors.Is(err, redis.ErrNil):
+		return errors.KindNotFound
+	case errors.Is(err, redis.ErrPoolExhausted):
+		return errors.KindUnavailable
+	}
+
+	replyErr, ok := errors.AsType[redis.Error](err)
+	if !ok {
+		return errors.KindUnknown
+	}
+
+	return replyErrorKind(string(replyErr))
+}
+
+// replyErrorKind returns the kind of the Redis error reply msg.
+func replyErrorKind(msg string) (k errors.Kind) {
+	switch {
+	case strings.HasPrefix(msg, ErrStrFunctionNotFound):
+		return errors.KindNotFound
+	case
+		strings.HasPrefix(msg, errPrefixBUSY),
+		strings.HasPrefix(msg, errPrefixLOADING),
+		strings.HasPrefix(msg, errPrefixMASTERDOWN),
+		strings.HasPrefix(msg, errPrefixREADONLY):
+		return errors.KindUnavailable
+	case strings.HasPrefix(msg, errPrefixTRYAGAIN):
+		return errors.KindTemporary
+	case strings.HasPrefix(msg, errPrefixWRONGTYPE):
+		return errors.KindInvalidArgument
+	default:
+		return errors.KindUnknown
+	}
+}
@@ -0,0 +1,74 @@
+package redisutil_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/AdguardTeam/golibs/redisutil"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKindOf(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		err  error
+		name string
+		want errors.Kind
+	}{{
+		err:  nil,
+		name: "nil",
+		want: errors.KindUnknown,
+	}, {
+		err:  assert.AnError,
+		name: "unknown",
+		want: errors.KindUnknown,
+	}, {
+		err:  fmt.Errorf("getting: %w", redis.ErrNil),
+		name: "nil_reply",
+		want: errors.KindNotFound,
+	}, {
+		err:  redis.ErrPoolExhausted,
+		name: "pool_exhausted",
+		want: errors.KindUnavailable,
+	}, {
+		err:  redis.Error(redisutil.ErrStrFunctionNotFound),
+		name: "function_not_found",
+		want: errors.KindNotFound,
+	}, {
+		err:  redis.Error("LOADING Redis is loading the dataset in memory"),
+		name: "loading",
+		want: errors.KindUnavailable,
+	}, {
+		err:  redis.Error("TRYAGAIN Multiple keys request during rehashing of slot"),
+		name: "try_again",
+		want: errors.KindTemporary,
+	}, {
+		err:  redis.Error("WRONGTYPE Operation against a key holding the wrong kind of value"),
+		name: "wrong_type",
+		want: errors.KindInvalidArgument,
+	}, {
+		err:  redis.Error("ERR unknown command"),
+		name: "other_reply",
+		want: errors.KindUnknown,
+	}, {
+		err:  fmt.Errorf("dialing: %w", context.DeadlineExceeded),
+		name: "timeout",
+		want: errors.KindTemporary,
+	}, {
+		err:  errors.WithKind(redis.ErrNil, errors.KindInvalidArgument),
+		name: "explicit",
+		want: errors.KindInvalidArgument,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, redisutil.KindOf(tc.err))
+		})
+	}
+}
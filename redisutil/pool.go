@@ -133,7 +133,9 @@ func NewDefaultPool(c *DefaultPoolConfig) (p *DefaultPool, err error) {
 var _ Pool = (*DefaultPool)(nil)
 
 // Get implements the [Pool] interface for *DefaultPool.  It returns a
-// connection from the pool and also updates the pool metrics.
+// connection from the pool and also updates the pool metrics.  Context errors,
+// such as [context.Canceled], are returned as is.  If any other error returned
+// has no known kind, see [KindOf], it has the kind [errors.KindUnavailable].
 func (p *DefaultPool) Get(ctx context.Context) (c redis.Conn, err error) {
 	c, err = p.pool.GetContext(ctx)
 
@@ -141,12 +143,23 @@ func (p *DefaultPool) Get(ctx context.Context) (c redis.Conn, err error) {
 	p.metrics.Update(ctx, stats, err)
 
 	if err != nil {
+		if !isContextError(err) && KindOf(err) == errors.KindUnknown {
+			err = errors.WithKind(err, errors.KindUnavailable)
+		}
+
 		return nil, err
 	}
 
 	return c, nil
 }
 
+// isContextError returns true if err is caused by the cancellation or the
+// deadline of a context, so that it must not be reported as an unavailability
+// of Redis.
+func isContextError(err error) (ok bool) {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
 // Close implements the [Pool] interface for *DefaultPool.
 func (p *DefaultPool) Close() (err error) { return p.pool.Close() }
 
@@ -2,9 +2,11 @@ package redisutil_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/AdguardTeam/golibs/errors"
 	"github.com/AdguardTeam/golibs/redisutil"
 	"github.com/AdguardTeam/golibs/testutil"
 	"github.com/AdguardTeam/golibs/testutil/fakeredis"
@@ -101,3 +103,58 @@ func TestDefaultPool_Get_integration(t *testing.T) {
 
 	assert.Equal(t, testValue, gotVal)
 }
+
+func TestDefaultPool_Get_error(t *testing.T) {
+	t.Parallel()
+
+	dialer := &fakeredis.Dialer{
+		OnDialContext: func(ctx context.Context) (c redis.Conn, err error) {
+			return nil, assert.AnError
+		},
+	}
+
+	p, err := redisutil.NewDefaultPool(&redisutil.DefaultPoolConfig{
+		Logger:          testLogger,
+		Dialer:          dialer,
+		MaxConnLifetime: redistest.MaxConnLifetime,
+		IdleTimeout:     redistest.IdleTimeout,
+		MaxActive:       redistest.MaxActive,
+		MaxIdle:         redistest.MaxIdle,
+	})
+	require.NoError(t, err)
+
+	ctx := testutil.ContextWithTimeout(t, testTimeout)
+	_, err = p.Get(ctx)
+	require.ErrorIs(t, err, assert.AnError)
+
+	assert.Equal(t, errors.KindUnavailable, errors.KindOf(err))
+}
+
+func TestDefaultPool_Get_canceled(t *testing.T) {
+	t.Parallel()
+
+	dialer := &fakeredis.Dialer{
+		OnDialContext: func(ctx context.Context) (c redis.Conn, err error) {
+			return nil, fmt.Errorf("dialing: %w", ctx.Err())
+		},
+	}
+
+	p, err := redisutil.NewDefaultPool(&redisutil.DefaultPoolConfig{
+		Logger:          testLogger,
+		Dialer:          dialer,
+		MaxConnLifetime: redistest.MaxConnLifetime,
+		IdleTimeout:     redistest.IdleTimeout,
+		MaxActive:       redistest.MaxActive,
+		MaxIdle:         redistest.MaxIdle,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(testutil.ContextWithTimeout(t, testTimeout))
+	cancel()
+
+	_, err = p.Get(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	assert.Equal(t, errors.KindUnknown, errors.KindOf(err))
+	assert.False(t, errors.KindOf(err).IsRetryable())
+}
@@ -31,16 +31,21 @@ const (
 	CmdFLUSHDB  = "FLUSHDB"
 	CmdFUNCTION = "FUNCTION"
 	CmdGET      = "GET"
+	CmdMGET     = "MGET"
 	CmdPTTL     = "PTTL"
 	CmdROLE     = "ROLE"
+	CmdSCAN     = "SCAN"
 	CmdSET      = "SET"
 )
 
 // Parameter constants.
 const (
 	ParamASYNC   = "ASYNC"
+	ParamCOUNT   = "COUNT"
 	ParamEX      = "EX"
+	ParamGET     = "GET"
 	ParamLOAD    = "LOAD"
+	ParamMATCH   = "MATCH"
 	ParamNX      = "NX"
 	ParamPX      = "PX"
 	ParamREPLACE = "REPLACE"
@@ -71,7 +71,6 @@ blocklist_imports() {
 		'(' -name '*.go' '!' -name '*.pb.go' ')' \
 		'!' '(' \
 		-path './cache/data.go' \
-		-o -path './cache/list.go' \
 		-o -path './errors/errors.go' \
 		-o -path './hostsfile/storage.go' \
 		-o -path './internal/reflectutil/reflectutil.go' \
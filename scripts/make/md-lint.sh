@@ -15,5 +15,6 @@ if [ "$verbose" -gt '0' ]; then
 fi
 
 markdownlint \
+	./CHANGELOG.md \
 	./README.md \
 	;
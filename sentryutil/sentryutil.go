@@ -21,7 +21,8 @@ const DefaultLoggerPrefix = "sentry_default_hub"
 const DSNEnv = "SENTRY_DSN"
 
 // InitDefaultHub initializes the default Sentry hub.  It returns an error if
-// [DSNEnv] isn't set in the environment.
+// [DSNEnv] isn't set in the environment.  Stack traces of errors created with
+// [errors.WithStack] are reported, see [AddErrorStack].
 func InitDefaultHub(release string) (err error) {
 	dsn := os.Getenv(DSNEnv)
 	if dsn == "" {
@@ -29,11 +30,36 @@ func InitDefaultHub(release string) (err error) {
 	}
 
 	return sentry.Init(sentry.ClientOptions{
-		Dsn:     dsn,
-		Release: release,
+		Dsn:        dsn,
+		Release:    release,
+		BeforeSend: AddErrorStack,
 	})
 }
 
+// type check
+var _ sentry.EventProcessor = AddErrorStack
+
+// AddErrorStack is a [sentry.EventProcessor] that replaces the stack trace of
+// the outermost exception of e with the stack trace recorded by
+// [errors.WithStack], if the captured error has one.  Otherwise, Sentry uses
+// the stack trace of the capturing call, which is usually less helpful.
+//
+// [InitDefaultHub] sets it as [sentry.ClientOptions.BeforeSend].
+func AddErrorStack(e *sentry.Event, hint *sentry.EventHint) (res *sentry.Event) {
+	if hint == nil || len(e.Exception) == 0 {
+		return e
+	}
+
+	st, ok := errors.AsType[errors.StackTracer](hint.OriginalException)
+	if !ok {
+		return e
+	}
+
+	e.Exception[len(e.Exception)-1].Stacktrace = sentry.ExtractStacktrace(st)
+
+	return e
+}
+
 // MustInitDefaultHub is like [InitDefaultHub] but panics on errors.  This
 // function should be used in a main function.
 func MustInitDefaultHub(release string) {
@@ -0,0 +1,94 @@
+package syncutil
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/AdguardTeam/golibs/errors"
+)
+
+// GroupConfig is the configuration structure for a *Group.
+type GroupConfig struct {
+	// Semaphore limits the number of goroutines running at the same time.  If
+	// it is nil, [EmptySemaphore] is used.
+	Semaphore Semaphore
+}
+
+// Group is a collection of goroutines working on subtasks of a common task.
+// Panics in the goroutines are recovered and returned from [Group.Wait] as
+// errors with stack traces, see [errors.WithStack], so the goroutines don't
+// need to recover themselves.  A Group can be reused after [Group.Wait] has
+// returned.  It must be initialized with [NewGroup].
+type Group struct {
+	sema Semaphore
+	wg   *sync.WaitGroup
+
+	// mu protects errs.
+	mu   *sync.Mutex
+	errs []error
+}
+
+// NewGroup returns a new properly initialized *Group.  c must not be nil.
+func NewGroup(c *GroupConfig) (g *Group) {
+	return &Group{
+		sema: cmp.Or[Semaphore](c.Semaphore, EmptySemaphore{}),
+		wg:   &sync.WaitGroup{},
+		mu:   &sync.Mutex{},
+	}
+}
+
+// Go waits until the semaphore of g is acquired and calls f in a new
+// goroutine.  ctx is only used for acquiring the semaphore; if that fails, err
+// is the error and f is not called.  Such errors are not returned by
+// [Group.Wait], so the caller must handle them.  f must not be nil.
+func (g *Group) Go(ctx context.Context, f func() (err error)) (err error) {
+	err = g.sema.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquiring semaphore: %w", err)
+	}
+
+	g.wg.Go(func() {
+		defer g.sema.Release()
+
+		err := call(f)
+		if err != nil {
+			g.mu.Lock()
+			defer g.mu.Unlock()
+
+			g.errs = append(g.errs, err)
+		}
+	})
+
+	return nil
+}
+
+// call calls f and converts a panic, if any, into err.
+func call(f func() (err error)) (err error) {
+	defer func() {
+		v := recover()
+		if v != nil {
+			// Create the error here, so that the stack trace includes the
+			// function that has panicked.
+			err = errors.WithStack(fmt.Errorf("panic: %w", errors.FromRecovered(v)))
+		}
+	}()
+
+	return f()
+}
+
+// Wait waits for all goroutines started with [Group.Go] to return and returns
+// their errors, including the ones converted from panics, joined with
+// [errors.Join].  The errors are then cleared, so that g can be reused.
+func (g *Group) Wait() (err error) {
+	g.wg.Wait()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err = errors.Join(g.errs...)
+	g.errs = nil
+
+	return err
+}
@@ -0,0 +1,37 @@
+package syncutil_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/AdguardTeam/golibs/syncutil"
+)
+
+func ExampleGroup() {
+	g := syncutil.NewGroup(&syncutil.GroupConfig{
+		Semaphore: syncutil.NewChanSemaphore(2),
+	})
+
+	ctx := context.Background()
+	for _, n := range []int{1, 2, 3} {
+		err := g.Go(ctx, func() (err error) {
+			if n == 3 {
+				panic(fmt.Errorf("bad number %d", n))
+			}
+
+			return nil
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	err := g.Wait()
+	fmt.Println(err)
+	fmt.Println(len(errors.Stack(err)) > 0)
+
+	// Output:
+	// panic: bad number 3
+	// true
+}
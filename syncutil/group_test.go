@@ -0,0 +1,113 @@
+package syncutil_test
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/AdguardTeam/golibs/syncutil"
+	"github.com/AdguardTeam/golibs/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// panickingFunc is a helper for TestGroup that panics with v.
+func panickingFunc(v any) (err error) {
+	panic(v)
+}
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	const testError errors.Error = "test error"
+
+	g := syncutil.NewGroup(&syncutil.GroupConfig{})
+	ctx := testutil.ContextWithTimeout(t, testTimeout)
+
+	require.NoError(t, g.Go(ctx, func() (err error) { return nil }))
+	require.NoError(t, g.Go(ctx, func() (err error) { return testError }))
+	require.NoError(t, g.Go(ctx, func() (err error) { return panickingFunc("boom") }))
+
+	err := g.Wait()
+	require.ErrorIs(t, err, testError)
+
+	msgs := strings.Split(err.Error(), "\n")
+	assert.ElementsMatch(t, []string{testError.Error(), "panic: recovered: boom"}, msgs)
+
+	frames := errors.Stack(err)
+	require.NotEmpty(t, frames)
+
+	var hasPanicking bool
+	for _, f := range frames {
+		hasPanicking = hasPanicking || strings.HasSuffix(f.Function, ".panickingFunc")
+	}
+
+	assert.True(t, hasPanicking)
+
+	// The errors must be cleared.
+	require.NoError(t, g.Go(ctx, func() (err error) { return nil }))
+	require.NoError(t, g.Wait())
+}
+
+func TestGroup_semaphore(t *testing.T) {
+	t.Parallel()
+
+	const (
+		maxRes       = 3
+		numGoroutine = 1_000
+	)
+
+	g := syncutil.NewGroup(&syncutil.GroupConfig{
+		Semaphore: syncutil.NewChanSemaphore(maxRes),
+	})
+
+	ctx := testutil.ContextWithTimeout(t, testTimeout)
+	current := &atomic.Int64{}
+	maxCurrent := &atomic.Int64{}
+
+	for range numGoroutine {
+		require.NoError(t, g.Go(ctx, func() (err error) {
+			defer current.Add(-1)
+
+			n := current.Add(1)
+			for prev := maxCurrent.Load(); n > prev; prev = maxCurrent.Load() {
+				if maxCurrent.CompareAndSwap(prev, n) {
+					break
+				}
+			}
+
+			return nil
+		}))
+	}
+
+	require.NoError(t, g.Wait())
+
+	assert.LessOrEqual(t, maxCurrent.Load(), int64(maxRes))
+}
+
+func TestGroup_Go_canceled(t *testing.T) {
+	t.Parallel()
+
+	g := syncutil.NewGroup(&syncutil.GroupConfig{
+		Semaphore: syncutil.NewChanSemaphore(1),
+	})
+
+	ctx := testutil.ContextWithTimeout(t, testTimeout)
+	unblock := make(chan struct{})
+	require.NoError(t, g.Go(ctx, func() (err error) {
+		<-unblock
+
+		return nil
+	}))
+
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	err := g.Go(canceledCtx, func() (err error) { panic("must not be called") })
+	require.ErrorIs(t, err, context.Canceled)
+
+	close(unblock)
+	assert.NoError(t, g.Wait())
+}
@@ -97,3 +97,60 @@ func AssertUnmarshalText[T any, U TextUnmarshaler[T]](t require.TestingT, s stri
 
 	return assert.Equal(t, want, got)
 }
+
+// AssertMarshalJSON checks that the implementation of [json.Marshaler] works in
+// all situations and results in the JSON document s.
+//
+// See https://github.com/dominikh/go-tools/issues/911.
+func AssertMarshalJSON[T json.Marshaler](t require.TestingT, s string, v *T) (ok bool) {
+	if h, isHelper := t.(interface{ Helper() }); isHelper {
+		h.Helper()
+	}
+
+	// Create a checker value.
+	checkerVal := newGenericCodecChecker(v)
+
+	// Get the expected value.
+	raw := json.RawMessage(s)
+	want, err := json.Marshal(newGenericCodecChecker(&raw))
+	require.NoErrorf(t, err, "marshaling expected value")
+
+	// Marshal and check against the expected value.
+	b, err := json.Marshal(checkerVal)
+	require.NoErrorf(t, err, "marshaling checker value")
+
+	return assert.Equal(t, string(want), string(b))
+}
+
+// JSONUnmarshaler is a constraint for pointer types that implement
+// json.Unmarshaler.
+type JSONUnmarshaler[T any] interface {
+	*T
+	json.Unmarshaler
+}
+
+// AssertUnmarshalJSON checks that the implementation of [json.Unmarshaler]
+// works in all situations and unmarshals from the JSON document s into a value
+// deeply equal to v.
+func AssertUnmarshalJSON[T any, U JSONUnmarshaler[T]](t require.TestingT, s string, v U) (ok bool) {
+	if h, isHelper := t.(interface{ Helper() }); isHelper {
+		h.Helper()
+	}
+
+	// Create the expected value.
+	want := newGenericCodecChecker(v)
+
+	// Create the checker value.
+	got := codecChecker[T]{}
+
+	// Marshal the expected data.
+	raw := json.RawMessage(s)
+	b, err := json.Marshal(newGenericCodecChecker(&raw))
+	require.NoErrorf(t, err, "marshaling checker value")
+
+	// Unmarshal into the checker value and compare.
+	err = json.Unmarshal(b, &got)
+	require.NoErrorf(t, err, "unmarshaling value")
+
+	return assert.Equal(t, want, got)
+}
@@ -2,6 +2,7 @@ package testutil_test
 
 import (
 	"encoding"
+	"encoding/json"
 	"testing"
 
 	"github.com/AdguardTeam/golibs/testutil"
@@ -60,6 +61,42 @@ func (c badCodec) UnmarshalText(b []byte) (err error) {
 	return nil
 }
 
+// goodJSONCodec is a good [json.Marshaler] and [json.Unmarshaler]
+// implementation.
+type goodJSONCodec struct {
+	value string
+}
+
+// type check
+var _ json.Marshaler = goodJSONCodec{}
+
+// MarshalJSON implements [json.Marshaler] for goodJSONCodec.
+func (c goodJSONCodec) MarshalJSON() (b []byte, err error) {
+	return json.Marshal(c.value)
+}
+
+// type check
+var _ json.Unmarshaler = (*goodJSONCodec)(nil)
+
+// UnmarshalJSON implements [json.Unmarshaler] for goodJSONCodec.
+func (c *goodJSONCodec) UnmarshalJSON(b []byte) (err error) {
+	return json.Unmarshal(b, &c.value)
+}
+
+// badJSONCodec is a bad [json.Unmarshaler] implementation.
+type badJSONCodec struct {
+	value string
+}
+
+// type check
+var _ json.Unmarshaler = badJSONCodec{}
+
+// UnmarshalJSON implements json.Unmarshaler for badJSONCodec.  It implements it
+// badly, because it uses a non-pointer receiver.
+func (c badJSONCodec) UnmarshalJSON(b []byte) (err error) {
+	return json.Unmarshal(b, &c.value)
+}
+
 func TestAssertMarshalText(t *testing.T) {
 	t.Parallel()
 
@@ -113,3 +150,54 @@ func TestAssertUnmarshalText(t *testing.T) {
 		assert.Greater(t, numHelper, 0)
 	}))
 }
+
+func TestAssertMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	numHelper := 0
+
+	tb := newTestTB()
+	tb.onHelper = func() { numHelper++ }
+
+	require.NotPanics(t, func() {
+		testutil.AssertMarshalJSON(tb, `"good"`, &goodJSONCodec{value: "good"})
+	})
+
+	assert.Greater(t, numHelper, 0)
+}
+
+func TestAssertUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, t.Run("good", func(t *testing.T) {
+		t.Parallel()
+
+		numHelper := 0
+
+		tb := newTestTB()
+		tb.onHelper = func() { numHelper++ }
+
+		require.NotPanics(t, func() {
+			testutil.AssertUnmarshalJSON(tb, `"good"`, &goodJSONCodec{value: "good"})
+		})
+		assert.Greater(t, numHelper, 0)
+	}))
+
+	require.True(t, t.Run("bad", func(t *testing.T) {
+		t.Parallel()
+
+		numHelper := 0
+		numErrorf := 0
+
+		tb := newTestTB()
+		tb.onErrorf = func(s string, _ ...any) { numErrorf++ }
+		tb.onHelper = func() { numHelper++ }
+		tb.onName = func() (name string) { return testName }
+
+		require.NotPanics(t, func() {
+			testutil.AssertUnmarshalJSON(tb, `"bad"`, &badJSONCodec{value: "bad"})
+		})
+		assert.Greater(t, numErrorf, 0)
+		assert.Greater(t, numHelper, 0)
+	}))
+}
@@ -41,7 +41,8 @@ func Append(errs []error, name string, v Interface) (res []error) {
 }
 
 // AppendSlice validates values, wraps errors with the name and the index,
-// appends them to errs, and returns the result.
+// appends them to errs, and returns the result.  To limit the number of errors
+// for large slices, use [CollectSlice].
 func AppendSlice[T Interface](errs []error, name string, values []T) (res []error) {
 	res = errs
 	for i, v := range values {
@@ -55,6 +56,17 @@ func AppendSlice[T Interface](errs []error, name string, values []T) (res []erro
 	return res
 }
 
+// CollectSlice is like [AppendSlice] but adds the errors to c, which limits
+// their number.  c must not be nil.
+func CollectSlice[T Interface](c *errors.Collector, name string, values []T) {
+	for i, v := range values {
+		err := v.Validate()
+		if err != nil {
+			c.Add(fmt.Errorf("%s: at index %d: %w", name, i, err))
+		}
+	}
+}
+
 // Slice validates values, wraps errors with the name and the index, and returns
 // the result as a single joined error.
 func Slice[T Interface](name string, values []T) (err error) {
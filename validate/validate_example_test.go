@@ -60,6 +60,34 @@ func ExampleSlice() {
 	// values: at index 2: test error 2
 }
 
+func ExampleCollectSlice() {
+	values := []*value{
+		0: {
+			err: errors.Error("test error"),
+		},
+		1: {
+			err: nil,
+		},
+		2: {
+			err: errors.Error("test error"),
+		},
+		3: {
+			err: errors.Error("test error"),
+		},
+	}
+
+	c := errors.NewCollector(2)
+	validate.CollectSlice(c, "values", values)
+
+	fmt.Println(c.Err())
+
+	// Output:
+	// 3 errors:
+	// 1. values: at index 0: test error
+	// 2. values: at index 2: test error
+	// and 1 more error
+}
+
 func ExampleEmpty() {
 	fmt.Println(validate.Empty("foo", "value"))
 	fmt.Println(validate.Empty("foo", ""))
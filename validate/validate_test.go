@@ -0,0 +1,84 @@
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/AdguardTeam/golibs/errors"
+	"github.com/AdguardTeam/golibs/testutil"
+	"github.com/AdguardTeam/golibs/validate"
+)
+
+func TestCollectSlice(t *testing.T) {
+	t.Parallel()
+
+	const testError errors.Error = "test error"
+
+	var (
+		badValue  = &value{err: testError}
+		goodValue = &value{}
+	)
+
+	testCases := []struct {
+		name    string
+		wantMsg string
+		values  []*value
+		limit   uint
+		times   uint
+	}{{
+		name:    "empty",
+		wantMsg: "",
+		values:  nil,
+		limit:   0,
+		times:   1,
+	}, {
+		name:    "good",
+		wantMsg: "",
+		values:  []*value{goodValue, goodValue},
+		limit:   0,
+		times:   1,
+	}, {
+		name:    "single",
+		wantMsg: "values: at index 1: test error",
+		values:  []*value{goodValue, badValue},
+		limit:   0,
+		times:   1,
+	}, {
+		name: "truncated",
+		wantMsg: "3 errors:\n" +
+			"1. values: at index 0: test error\n" +
+			"2. values: at index 1: test error\n" +
+			"and 1 more error",
+		values: []*value{badValue, badValue, goodValue, badValue},
+		limit:  2,
+		times:  1,
+	}, {
+		name: "dedup",
+		wantMsg: "4 errors:\n" +
+			"1. values: at index 0: test error (2 times)\n" +
+			"2. values: at index 2: test error (2 times)",
+		values: []*value{badValue, goodValue, badValue},
+		limit:  0,
+		times:  2,
+	}, {
+		name: "dedup_truncated",
+		wantMsg: "6 errors:\n" +
+			"1. values: at index 0: test error (2 times)\n" +
+			"and 4 more errors",
+		values: []*value{badValue, badValue, badValue},
+		limit:  1,
+		times:  2,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := errors.NewCollector(tc.limit)
+			for range tc.times {
+				validate.CollectSlice(c, "values", tc.values)
+			}
+
+			testutil.AssertErrorMsg(t, tc.wantMsg, c.Err())
+		})
+	}
+}